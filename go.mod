@@ -2,7 +2,10 @@ module vaultview
 
 go 1.23.0
 
-require github.com/gdamore/tcell v1.4.0
+require (
+	github.com/gdamore/tcell v1.4.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/gdamore/encoding v1.0.0 // indirect
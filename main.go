@@ -1,12 +1,16 @@
 package main
 
 import (
+	"flag"
 	"vaultview/pkg/tui"
 )
 
 func main() {
+	contextName := flag.String("context", "", "name of the context from the config file")
+	flag.Parse()
+
 	tui := tui.NewTui()
-	tui.Init()
+	tui.Init(*contextName)
 	if err := tui.Run(); err != nil {
 		panic(err)
 	}
@@ -1,9 +1,21 @@
 package config
 
-import "strings"
+import (
+	"os"
+	"strconv"
+	"strings"
+)
 
 type Config struct {
-	VaultAddr string
+	VaultAddr     string
+	Context       string
+	Namespace     string
+	AuthMethod    string
+	AuthMount     string
+	AuthRole      string
+	TokenSource   string
+	TLS           TLSConfig
+	DefaultEngine string
 }
 
 func NewConfig() *Config {
@@ -14,3 +26,38 @@ func (cfg *Config) UpdateVaultAddr(value string) {
 	v := strings.TrimSuffix(value, "/")
 	cfg.VaultAddr = v
 }
+
+func (cfg *Config) UpdateNamespace(value string) {
+	cfg.Namespace = strings.Trim(strings.TrimSpace(value), "/")
+}
+
+// UseContext replaces current configuration with the context
+func (cfg *Config) UseContext(ctx Context) {
+	cfg.UpdateVaultAddr(ctx.Address)
+	cfg.Context = ctx.Name
+	cfg.UpdateNamespace(ctx.Namespace)
+	cfg.AuthMethod = ctx.AuthMethod
+	cfg.AuthMount = ctx.AuthMount
+	cfg.AuthRole = ctx.AuthRole
+	cfg.TLS = ctx.TLS
+	cfg.DefaultEngine = ctx.DefaultEngine
+}
+
+// UpdateTLSFromEnv fills TLS settings missing in the context from VAULT_CACERT, VAULT_CAPATH,
+// VAULT_CLIENT_CERT, VAULT_CLIENT_KEY, VAULT_TLS_SERVER_NAME and VAULT_SKIP_VERIFY
+func (cfg *Config) UpdateTLSFromEnv() {
+	setFromEnv(&cfg.TLS.CACert, "VAULT_CACERT")
+	setFromEnv(&cfg.TLS.CAPath, "VAULT_CAPATH")
+	setFromEnv(&cfg.TLS.ClientCert, "VAULT_CLIENT_CERT")
+	setFromEnv(&cfg.TLS.ClientKey, "VAULT_CLIENT_KEY")
+	setFromEnv(&cfg.TLS.ServerName, "VAULT_TLS_SERVER_NAME")
+	if skip, err := strconv.ParseBool(os.Getenv("VAULT_SKIP_VERIFY")); err == nil && skip {
+		cfg.TLS.SkipVerify = true
+	}
+}
+
+func setFromEnv(field *string, env string) {
+	if *field == "" {
+		*field = os.Getenv(env)
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configDir  = "vaultview"
+	configFile = "config.yaml"
+)
+
+type TLSConfig struct {
+	CACert     string `yaml:"caCert,omitempty"`
+	CAPath     string `yaml:"caPath,omitempty"`
+	ClientCert string `yaml:"clientCert,omitempty"`
+	ClientKey  string `yaml:"clientKey,omitempty"`
+	ServerName string `yaml:"serverName,omitempty"`
+	SkipVerify bool   `yaml:"skipVerify,omitempty"`
+}
+
+// Context is named vault cluster configuration
+type Context struct {
+	Name          string    `yaml:"name"`
+	Address       string    `yaml:"address"`
+	Namespace     string    `yaml:"namespace,omitempty"`
+	AuthMethod    string    `yaml:"authMethod,omitempty"`
+	AuthMount     string    `yaml:"authMount,omitempty"`
+	AuthRole      string    `yaml:"authRole,omitempty"`
+	TLS           TLSConfig `yaml:"tls,omitempty"`
+	DefaultEngine string    `yaml:"defaultEngine,omitempty"`
+}
+
+// File is vaultview configuration file stored in XDG config dir
+type File struct {
+	CurrentContext string    `yaml:"currentContext,omitempty"`
+	Contexts       []Context `yaml:"contexts"`
+	path           string
+}
+
+// FilePath returns $XDG_CONFIG_HOME/vaultview/config.yaml (~/.config/vaultview/config.yaml by default)
+func FilePath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, configDir, configFile), nil
+}
+
+// LoadFile reads configuration file, missing file results in empty configuration
+func LoadFile() (*File, error) {
+	path, err := FilePath()
+	if err != nil {
+		return &File{}, err
+	}
+	f := &File{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return f, err
+	}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return f, fmt.Errorf("invalid config file '%s': %v", path, err)
+	}
+	return f, nil
+}
+
+func (f *File) Context(name string) (Context, bool) {
+	for _, ctx := range f.Contexts {
+		if ctx.Name == name {
+			return ctx, true
+		}
+	}
+	return Context{}, false
+}
+
+// Save writes configuration file, used to remember current context
+func (f *File) Save() error {
+	if f.path == "" {
+		return fmt.Errorf("config file path is not set")
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, data, 0o600)
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	TokenSourceEnv    = "env VAULT_TOKEN"
+	TokenSourceFile   = "~/.vault-token"
+	TokenSourceDialog = "configuration dialog"
+)
+
+// ResolveToken looks for a token the same way vault cli does: VAULT_TOKEN env var,
+// token helper configured in ~/.vault (or VAULT_CONFIG_PATH) and ~/.vault-token file.
+// Empty token is returned when none of the sources has it.
+func ResolveToken() (token, source string, err error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, TokenSourceEnv, nil
+	}
+	helper, err := tokenHelper()
+	if err != nil {
+		return "", "", err
+	}
+	if helper != "" {
+		token, err := runTokenHelper(helper)
+		if err != nil {
+			return "", "", err
+		}
+		return token, fmt.Sprintf("token helper %s", filepath.Base(helper)), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", err
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".vault-token"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(string(data)), TokenSourceFile, nil
+}
+
+// tokenHelper reads token_helper from vault cli configuration file
+func tokenHelper() (string, error) {
+	path := os.Getenv("VAULT_CONFIG_PATH")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, ".vault")
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	// only token_helper = "..." line is interesting, rest of the hcl file is ignored
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if ok && strings.TrimSpace(key) == "token_helper" {
+			return strings.Trim(strings.TrimSpace(value), `"`), nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+func runTokenHelper(helper string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, helper, "get")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("token helper '%s' failed: %v %s", helper, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
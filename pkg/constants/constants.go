@@ -5,15 +5,32 @@ const (
 	SecretsTitle       = "Secrets"
 	SecretEnginesTitle = "[Secret Engines]"
 	PathTitle          = "Secret Path"
+	VersionsTitle      = "Versions"
+	ConflictTitle      = "Conflict"
+	ContextsTitle      = "Contexts"
+	NamespacesTitle    = "Namespaces"
+	PoliciesTitle      = "Policies"
+	AuthMethodsTitle   = "Auth Methods"
+	SearchTitle        = "Search"
+	GrepTitle          = "Grep"
 )
 
 const (
-	MainPage          = "page_Main"
-	ModalPage         = "page_Modal"
-	ViewSecretEngines = "view_SecretEngines"
-	ViewSecrets       = "view_Secrets"
-	ViewSecretData    = "view_SecretData"
-	ViewHeader        = "view_Header"
+	MainPage           = "page_Main"
+	ModalPage          = "page_Modal"
+	ViewSecretEngines  = "view_SecretEngines"
+	ViewSecrets        = "view_Secrets"
+	ViewSecretData     = "view_SecretData"
+	ViewSecretVersions = "view_SecretVersions"
+	ViewSecretConflict = "view_SecretConflict"
+	ViewContexts       = "view_Contexts"
+	ViewNamespaces     = "view_Namespaces"
+	ViewPolicies       = "view_Policies"
+	ViewAuthMethods    = "view_AuthMethods"
+	ViewSearch         = "view_Search"
+	ViewGrep           = "view_Grep"
+	ViewTree           = "view_Tree"
+	ViewHeader         = "view_Header"
 )
 
 const (
@@ -22,7 +39,27 @@ const (
 )
 
 const (
-	Edit   = 'e'
-	Copy   = 'c'
-	Reveal = 'x'
+	Edit      = 'e'
+	Copy      = 'c'
+	Reveal    = 'x'
+	History   = 'h'
+	Rollback  = 'r'
+	Diff      = 'd'
+	New       = 'n'
+	AddKey    = 'a'
+	RenameKey = 'm'
+	Delete    = 'D'
+	Undelete  = 'u'
+	Destroy   = 'X'
+	Purge     = 'P'
+	EditJSON  = 'J'
+	EditYAML  = 'Y'
+	Filter    = '/'
+	Search    = 's'
+	Grep      = 'g'
+	Tree      = 't'
+
+	TakeMine   = '1'
+	TakeBase   = '2'
+	TakeRemote = '3'
 )
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"vaultview/pkg/vault"
+)
+
+// CrawlWorkers is number of folders listed concurrently
+const CrawlWorkers = 8
+
+// CrawlStats describes finished (or stopped) crawl
+type CrawlStats struct {
+	Folders int
+	Secrets int
+	Failed  int
+	Stopped bool
+}
+
+// Crawler walks kv engine recursively, listed is called for every listed folder
+// and found for every path (secret or folder) accepted by match
+type Crawler struct {
+	vault   vault.VaultSvc
+	engine  string
+	match   func(p string) bool
+	listed  func(dir string, items []string)
+	found   func(p string)
+	stop    chan struct{}
+	once    sync.Once
+	folders atomic.Int64
+	secrets atomic.Int64
+	failed  atomic.Int64
+}
+
+func NewCrawler(vaultCli vault.VaultSvc, engine string, match func(p string) bool, listed func(dir string, items []string), found func(p string)) *Crawler {
+	return &Crawler{
+		vault:  vaultCli,
+		engine: engine,
+		match:  match,
+		listed: listed,
+		found:  found,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Run walks folders below root (root is "" or path ending with '/') until all folders are listed or Stop is called,
+// folders which cannot be listed (e.g. denied by policy) are skipped
+func (c *Crawler) Run(root string) CrawlStats {
+	sem := make(chan struct{}, CrawlWorkers)
+	var wg sync.WaitGroup
+	var walk func(dir string)
+	walk = func(dir string) {
+		defer wg.Done()
+		select {
+		case <-c.stop:
+			return
+		case sem <- struct{}{}:
+		}
+		if c.Stopped() {
+			<-sem
+			return
+		}
+		items, err := c.vault.ListKvSecrets(c.engine, dir)
+		<-sem
+		if err != nil {
+			c.failed.Add(1)
+			return
+		}
+		c.folders.Add(1)
+		c.listed(dir, items)
+		for _, item := range items {
+			p := dir + item
+			if c.match(p) {
+				c.found(p)
+			}
+			if strings.HasSuffix(item, "/") {
+				wg.Add(1)
+				go walk(p)
+			} else {
+				c.secrets.Add(1)
+			}
+		}
+	}
+	wg.Add(1)
+	walk(root)
+	wg.Wait()
+	return c.Stats()
+}
+
+// Stats returns progress of the crawl
+func (c *Crawler) Stats() CrawlStats {
+	return CrawlStats{
+		Folders: int(c.folders.Load()),
+		Secrets: int(c.secrets.Load()),
+		Failed:  int(c.failed.Load()),
+		Stopped: c.Stopped(),
+	}
+}
+
+// Stop cancels the crawl, requests in flight are finished but no new folders are listed
+func (c *Crawler) Stop() {
+	c.once.Do(func() {
+		close(c.stop)
+	})
+}
+
+func (c *Crawler) Stopped() bool {
+	select {
+	case <-c.stop:
+		return true
+	default:
+		return false
+	}
+}
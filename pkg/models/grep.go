@@ -0,0 +1,148 @@
+package models
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"vaultview/pkg/vault"
+)
+
+const (
+	// GrepWorkers is number of secrets read concurrently
+	GrepWorkers = 4
+	// GrepRate is maximum number of secrets read per second
+	GrepRate = 20
+)
+
+// GrepScope selects what is matched in secrets
+type GrepScope int
+
+const (
+	GrepAll GrepScope = iota
+	GrepKeys
+	GrepValues
+)
+
+// GrepHit is key of the secret matching the pattern (by name or value)
+type GrepHit struct {
+	Path    string
+	Key     string
+	Value   vault.SecretValue
+	InKey   bool
+	InValue bool
+}
+
+// GrepStats describes progress of the grep
+type GrepStats struct {
+	Secrets int
+	Read    int
+	Hits    int
+	Failed  int
+	Stopped bool
+}
+
+// Grep reads all secrets below the path (secrets are found by Crawler) and reports keys matching the pattern,
+// reads are rate limited to GrepRate secrets per second
+type Grep struct {
+	vault   vault.VaultSvc
+	engine  string
+	match   func(s string) bool
+	scope   GrepScope
+	hit     func(h GrepHit)
+	crawler *Crawler
+	paths   chan string
+	secrets atomic.Int64
+	read    atomic.Int64
+	hits    atomic.Int64
+	failed  atomic.Int64
+}
+
+// NewGrep creates grep of the engine, listed is called for every folder listed while looking for secrets
+func NewGrep(vaultCli vault.VaultSvc, engine string, match func(s string) bool, scope GrepScope, listed func(dir string, items []string), hit func(h GrepHit)) *Grep {
+	g := &Grep{
+		vault:  vaultCli,
+		engine: engine,
+		match:  match,
+		scope:  scope,
+		hit:    hit,
+		paths:  make(chan string),
+	}
+	// secrets found by the crawler are passed to the readers
+	g.crawler = NewCrawler(vaultCli, engine, func(p string) bool {
+		return !strings.HasSuffix(p, "/")
+	}, listed, func(p string) {
+		g.secrets.Add(1)
+		select {
+		case g.paths <- p:
+		case <-g.crawler.stop:
+		}
+	})
+	return g
+}
+
+// Run reads secrets below root until all of them are read or Stop is called
+func (g *Grep) Run(root string) GrepStats {
+	go func() {
+		g.crawler.Run(root)
+		close(g.paths)
+	}()
+
+	limiter := time.NewTicker(time.Second / GrepRate)
+	defer limiter.Stop()
+	var wg sync.WaitGroup
+	for i := 0; i < GrepWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range g.paths {
+				select {
+				case <-g.crawler.stop:
+					continue
+				case <-limiter.C:
+				}
+				g.grep(p)
+			}
+		}()
+	}
+	wg.Wait()
+	return g.Stats()
+}
+
+func (g *Grep) grep(p string) {
+	secret, _, err := g.vault.ReadKvSecret(g.engine, p)
+	g.read.Add(1)
+	if err != nil {
+		g.failed.Add(1)
+		return
+	}
+	for key, value := range secret {
+		h := GrepHit{
+			Path:    p,
+			Key:     key,
+			Value:   value,
+			InKey:   g.scope != GrepValues && g.match(key),
+			InValue: g.scope != GrepKeys && g.match(value.Text),
+		}
+		if h.InKey || h.InValue {
+			g.hits.Add(1)
+			g.hit(h)
+		}
+	}
+}
+
+// Stats returns progress of the grep
+func (g *Grep) Stats() GrepStats {
+	return GrepStats{
+		Secrets: int(g.secrets.Load()),
+		Read:    int(g.read.Load()),
+		Hits:    int(g.hits.Load()),
+		Failed:  int(g.failed.Load()),
+		Stopped: g.crawler.Stopped(),
+	}
+}
+
+// Stop cancels the grep, secrets which are not read yet are skipped
+func (g *Grep) Stop() {
+	g.crawler.Stop()
+}
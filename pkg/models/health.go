@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+const healthInterval = 10 * time.Second
+
+// HealthWatcher polls sys/health and updates Info, alert is called when vault
+// becomes sealed or unreachable and recovered when it is healthy again
+type HealthWatcher struct {
+	info      *Info
+	alert     func(msg string)
+	recovered func(msg string)
+	stop      chan struct{}
+}
+
+func NewHealthWatcher(info *Info, alert, recovered func(msg string)) *HealthWatcher {
+	return &HealthWatcher{
+		info:      info,
+		alert:     alert,
+		recovered: recovered,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Run polls health until Stop is called
+func (hw *HealthWatcher) Run() {
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-hw.stop:
+			return
+		case <-ticker.C:
+			hw.check()
+		}
+	}
+}
+
+func (hw *HealthWatcher) Stop() {
+	close(hw.stop)
+}
+
+func (hw *HealthWatcher) check() {
+	vs, err := hw.info.getVaultInfo()
+	var wasHealthy, healthy bool
+	hw.info.update(func(i *Info) {
+		wasHealthy = i.Healthy
+		if err != nil {
+			i.setUnreachable()
+		} else {
+			i.setHealth(vs)
+		}
+		healthy = i.Healthy
+	})
+	switch {
+	case wasHealthy && !healthy && err != nil:
+		hw.alert(fmt.Sprintf("vault is unreachable: %v", err))
+	case wasHealthy && !healthy:
+		hw.alert("vault is sealed")
+	case !wasHealthy && healthy:
+		hw.recovered("vault is healthy again")
+	}
+}
@@ -1,10 +1,15 @@
 package models
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 	"vaultview/pkg/config"
 	"vaultview/pkg/vault"
 )
@@ -17,15 +22,31 @@ type Info struct {
 	VaultViewRev        string
 	VaultRev            string
 	VaultAddr           string
+	Namespace           string
 	Sealed              string
+	Initialized         string
+	ClusterName         string
+	HAMode              string
+	Replication         string
+	Healthy             bool
 	TokenPolicies       string
+	TokenSource         string
 	TokenExpirationTime string
+	TokenTTL            string
 	listeners           []InfoListeners
+	client              *http.Client
+	mx                  *sync.Mutex
 }
 
 type vaultResponse struct {
-	Sealed  *bool  `json:"sealed"`
-	Version string `json:"version"`
+	Sealed                     *bool  `json:"sealed"`
+	Version                    string `json:"version"`
+	Initialized                *bool  `json:"initialized"`
+	Standby                    bool   `json:"standby"`
+	PerformanceStandby         bool   `json:"performance_standby"`
+	ReplicationDRMode          string `json:"replication_dr_mode"`
+	ReplicationPerformanceMode string `json:"replication_performance_mode"`
+	ClusterName                string `json:"cluster_name"`
 }
 
 var (
@@ -36,57 +57,140 @@ func NewInfo(vaultCli vault.VaultSvc, cfg *config.Config) (*Info, error) {
 	info := &Info{
 		VaultViewRev: version,
 		VaultAddr:    cfg.VaultAddr,
+		Namespace:    namespace(cfg.Namespace),
+		TokenSource:  cfg.TokenSource,
 		Sealed:       "",
+		client:       vaultCli.HTTPClient(),
+		mx:           &sync.Mutex{},
 	}
-	vs, err := info.getVaultInfo()
-	if err != nil {
+	if err := info.checkHealth(); err != nil {
 		return info, err
 	}
-
-	info.VaultRev = vs.Version
-	if vs.Sealed == nil {
-		info.Sealed = ""
-	} else if *vs.Sealed {
-		info.Sealed = "true"
-	} else {
-		info.Sealed = "false"
-	}
 	tokenInfo, err := vaultCli.ReadTokenInfo()
 	if err != nil {
-		return info, err
+		return info, &StepError{Step: StepTokenInfo, Err: err}
 	}
 	info.TokenPolicies = tokenInfo["policies"]
 	info.TokenExpirationTime = tokenInfo["expire_time"]
 	return info, err
 }
 
+// CheckHealth returns error when vault is unreachable or sealed
+func CheckHealth(vaultCli vault.VaultSvc, addr string) error {
+	info := &Info{VaultAddr: addr, client: vaultCli.HTTPClient()}
+	return info.checkHealth()
+}
+
+func (i *Info) checkHealth() error {
+	vs, err := i.getVaultInfo()
+	if err != nil {
+		return &StepError{Step: StepHealth, Err: err}
+	}
+	i.setHealth(vs)
+	if vs.Initialized != nil && !*vs.Initialized {
+		return &StepError{Step: StepHealth, Err: errors.New("vault is not initialized")}
+	}
+	if !i.Healthy {
+		return &StepError{Step: StepHealth, Err: errors.New("vault is sealed")}
+	}
+	return nil
+}
+
+func namespace(ns string) string {
+	if ns == "" {
+		return "root"
+	}
+	return ns
+}
+
 func (i *Info) getVaultInfo() (vaultResponse, error) {
 	var vr vaultResponse
 
 	//todo: make sure to remove last '/' from vault addr
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/sys/health", i.VaultAddr), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/sys/health", i.VaultAddr), nil)
 	if err != nil {
 		return vaultResponse{}, err
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := i.client.Do(req)
 	if err != nil {
 		return vaultResponse{}, err
 	}
+	defer res.Body.Close()
 
+	// sealed, standby and uninitialized vaults respond with non 200 status codes, body has the details
 	response, err := io.ReadAll(res.Body)
+	if err != nil {
+		return vaultResponse{}, err
+	}
 	if err := json.Unmarshal(response, &vr); err != nil {
 		return vaultResponse{}, err
 	}
 	return vr, nil
 }
 
+func (i *Info) setHealth(vs vaultResponse) {
+	i.VaultRev = vs.Version
+	i.Sealed = boolValue(vs.Sealed)
+	i.Initialized = boolValue(vs.Initialized)
+	i.ClusterName = vs.ClusterName
+	i.Healthy = vs.Sealed != nil && !*vs.Sealed
+	switch {
+	case vs.PerformanceStandby:
+		i.HAMode = "performance standby"
+	case vs.Standby:
+		i.HAMode = "standby"
+	default:
+		i.HAMode = "active"
+	}
+	i.Replication = replication(vs.ReplicationDRMode, vs.ReplicationPerformanceMode)
+}
+
+func (i *Info) setUnreachable() {
+	i.Sealed = "unreachable"
+	i.Initialized = ""
+	i.HAMode = ""
+	i.Replication = ""
+	i.Healthy = false
+}
+
+func boolValue(b *bool) string {
+	if b == nil {
+		return ""
+	} else if *b {
+		return "true"
+	}
+	return "false"
+}
+
+func replication(dr, performance string) string {
+	var modes []string
+	if dr != "" {
+		modes = append(modes, "dr: "+dr)
+	}
+	if performance != "" {
+		modes = append(modes, "perf: "+performance)
+	}
+	return strings.Join(modes, ", ")
+}
+
 func (i *Info) RegisterListener(listener InfoListeners) {
 	i.listeners = append(i.listeners, listener)
 }
 
 func (i *Info) TriggerInfoChange() {
-	for _, l := range i.listeners {
-		l.UpdateInfoTable(*i)
+	i.update(func(*Info) {})
+}
+
+// update changes info under lock (info is updated by background watchers) and notifies listeners
+func (i *Info) update(f func(i *Info)) {
+	i.mx.Lock()
+	f(i)
+	info := *i
+	i.mx.Unlock()
+	for _, l := range info.listeners {
+		l.UpdateInfoTable(info)
 	}
 }
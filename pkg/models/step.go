@@ -0,0 +1,24 @@
+package models
+
+import "fmt"
+
+const (
+	StepConnect    = "vault client"
+	StepHealth     = "vault health check"
+	StepTokenInfo  = "token lookup"
+	StepSecretList = "listing secret engines"
+)
+
+// StepError is startup error with the step which failed
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("%s failed: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
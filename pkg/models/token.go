@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"vaultview/pkg/vault"
+)
+
+const (
+	tokenTick = time.Second
+	// token is renewed when less than 1/3 of the granted ttl remains
+	tokenRenewRatio = 3
+	// failed renewal is retried after
+	tokenRenewRetry = 30 * time.Second
+	// warnings are raised when token expires within
+	tokenWarnBefore = 10 * time.Minute
+	tokenWarnEvery  = time.Minute
+)
+
+// TokenWatcher counts down token ttl in Info, renews renewable tokens before they expire
+// and warns when token is close to expiry or cannot be renewed anymore
+type TokenWatcher struct {
+	vault     vault.VaultSvc
+	info      *Info
+	warn      func(msg string)
+	stop      chan struct{}
+	status    vault.TokenStatus
+	granted   time.Duration
+	nextRenew time.Time
+	lastWarn  time.Time
+}
+
+func NewTokenWatcher(vaultCli vault.VaultSvc, info *Info, warn func(msg string)) *TokenWatcher {
+	return &TokenWatcher{
+		vault: vaultCli,
+		info:  info,
+		warn:  warn,
+		stop:  make(chan struct{}),
+	}
+}
+
+// Run watches the token until Stop is called or token expires
+func (tw *TokenWatcher) Run() {
+	status, err := tw.vault.ReadTokenStatus()
+	if err != nil {
+		tw.warn(fmt.Sprintf("token lookup failed: %v", err))
+		return
+	}
+	if status.ExpireTime.IsZero() {
+		// token never expires
+		return
+	}
+	tw.setStatus(status)
+	if !status.Renewable {
+		tw.warn(fmt.Sprintf("token is not renewable, expires in %s", formatTTL(status.TTL)))
+		tw.lastWarn = time.Now()
+	}
+
+	ticker := time.NewTicker(tokenTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tw.stop:
+			return
+		case now := <-ticker.C:
+			if !tw.check(now) {
+				return
+			}
+		}
+	}
+}
+
+func (tw *TokenWatcher) Stop() {
+	close(tw.stop)
+}
+
+// check updates countdown, renews or warns, false is returned when token has expired
+func (tw *TokenWatcher) check(now time.Time) bool {
+	remaining := tw.status.ExpireTime.Sub(now)
+	tw.info.update(func(i *Info) {
+		i.TokenTTL = formatTTL(remaining)
+	})
+	if remaining <= 0 {
+		tw.warn("token has expired, reconnect to continue")
+		return false
+	}
+
+	if tw.status.Renewable && remaining < tw.granted/tokenRenewRatio && now.After(tw.nextRenew) {
+		tw.renew(now)
+		return true
+	}
+
+	// renewable tokens are not reported unless renewal fails
+	renewing := tw.status.Renewable && !now.Before(tw.nextRenew)
+	if !renewing && remaining < tokenWarnBefore && now.Sub(tw.lastWarn) >= tokenWarnEvery {
+		tw.warn(fmt.Sprintf("token expires in %s", formatTTL(remaining)))
+		tw.lastWarn = now
+	}
+	return true
+}
+
+func (tw *TokenWatcher) renew(now time.Time) {
+	status, err := tw.vault.RenewToken()
+	if err != nil {
+		tw.nextRenew = now.Add(tokenRenewRetry)
+		tw.warn(fmt.Sprintf("token renewal failed: %v", err))
+		tw.lastWarn = now
+		return
+	}
+	if !status.Renewable {
+		tw.warn(fmt.Sprintf("token is no longer renewable, expires in %s", formatTTL(status.ExpireTime.Sub(now))))
+		tw.lastWarn = now
+	} else if !status.ExpireTime.After(tw.status.ExpireTime) {
+		// vault did not extend the token, it has reached its max ttl
+		status.Renewable = false
+		tw.warn(fmt.Sprintf("token reached max TTL, expires in %s", formatTTL(status.ExpireTime.Sub(now))))
+		tw.lastWarn = now
+	}
+	tw.setStatus(status)
+}
+
+func (tw *TokenWatcher) setStatus(status vault.TokenStatus) {
+	tw.status = status
+	tw.granted = status.TTL
+	tw.info.update(func(i *Info) {
+		i.TokenExpirationTime = status.ExpireTime.Format(time.RFC3339Nano)
+	})
+}
+
+func formatTTL(ttl time.Duration) string {
+	if ttl <= 0 {
+		return "expired"
+	}
+	return ttl.Truncate(time.Second).String()
+}
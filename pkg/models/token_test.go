@@ -0,0 +1,134 @@
+package models
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"vaultview/pkg/vault"
+)
+
+// fakeTokenVault renews token by the ttl until max expire time is reached
+type fakeTokenVault struct {
+	vault.VaultSvc
+	mx        sync.Mutex
+	status    vault.TokenStatus
+	maxExpire time.Time
+	renewals  int
+}
+
+func (f *fakeTokenVault) ReadTokenStatus() (vault.TokenStatus, error) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	return f.status, nil
+}
+
+func (f *fakeTokenVault) RenewToken() (vault.TokenStatus, error) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	f.renewals++
+	expire := time.Now().Add(f.status.TTL)
+	if expire.After(f.maxExpire) {
+		expire = f.maxExpire
+	}
+	f.status.ExpireTime = expire
+	return f.status, nil
+}
+
+type countingListener struct {
+	updates atomic.Int64
+}
+
+func (l *countingListener) UpdateInfoTable(info Info) {
+	_ = info.TokenTTL + info.TokenExpirationTime + info.Sealed
+	l.updates.Add(1)
+}
+
+func newTestInfo() (*Info, *countingListener) {
+	l := &countingListener{}
+	info := &Info{mx: &sync.Mutex{}}
+	info.RegisterListener(l)
+	return info, l
+}
+
+func TestTokenWatcherRenewsBeforeExpiry(t *testing.T) {
+	now := time.Now()
+	fake := &fakeTokenVault{
+		status:    vault.TokenStatus{ExpireTime: now.Add(time.Hour), TTL: time.Hour, Renewable: true},
+		maxExpire: now.Add(24 * time.Hour),
+	}
+	info, _ := newTestInfo()
+	var warnings []string
+	tw := NewTokenWatcher(fake, info, func(msg string) { warnings = append(warnings, msg) })
+	tw.setStatus(fake.status)
+
+	if !tw.check(now.Add(30 * time.Minute)) {
+		t.Fatal("token is not expired yet")
+	}
+	if fake.renewals != 0 {
+		t.Errorf("token renewed while more than 1/%d of ttl remains", tokenRenewRatio)
+	}
+	if !tw.check(now.Add(50 * time.Minute)) {
+		t.Fatal("token is not expired yet")
+	}
+	if fake.renewals != 1 {
+		t.Errorf("expected 1 renewal, got %d", fake.renewals)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("renewable token is reported: %v", warnings)
+	}
+}
+
+func TestTokenWatcherWarnsOnMaxTTL(t *testing.T) {
+	now := time.Now()
+	fake := &fakeTokenVault{
+		status:    vault.TokenStatus{ExpireTime: now.Add(time.Hour), TTL: time.Hour, Renewable: true},
+		maxExpire: now.Add(time.Hour),
+	}
+	info, _ := newTestInfo()
+	var warnings []string
+	tw := NewTokenWatcher(fake, info, func(msg string) { warnings = append(warnings, msg) })
+	tw.setStatus(fake.status)
+
+	tw.check(now.Add(50 * time.Minute))
+	if tw.status.Renewable {
+		t.Error("token which was not extended is still treated as renewable")
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected max ttl warning, got %v", warnings)
+	}
+	if tw.check(now.Add(2 * time.Hour)) {
+		t.Error("expired token is still watched")
+	}
+}
+
+// TestTokenWatcherConcurrentInfo is meant for -race, token countdown is written while header reads info
+func TestTokenWatcherConcurrentInfo(t *testing.T) {
+	now := time.Now()
+	fake := &fakeTokenVault{
+		status:    vault.TokenStatus{ExpireTime: now.Add(time.Hour), TTL: time.Hour, Renewable: true},
+		maxExpire: now.Add(24 * time.Hour),
+	}
+	info, listener := newTestInfo()
+	tw := NewTokenWatcher(fake, info, func(string) {})
+	tw.setStatus(fake.status)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			tw.check(now.Add(time.Duration(i) * time.Second))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			info.TriggerInfoChange()
+		}
+	}()
+	wg.Wait()
+	if listener.updates.Load() < 200 {
+		t.Errorf("expected at least 200 updates, got %d", listener.updates.Load())
+	}
+}
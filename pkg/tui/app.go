@@ -1,12 +1,17 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 	"vaultview/pkg/config"
 	"vaultview/pkg/constants"
+	"vaultview/pkg/models"
 	"vaultview/pkg/vault"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -14,10 +19,16 @@ type Tui struct {
 	App              *tview.Application
 	pages            *tview.Pages
 	vaultConfigModal *ModalInput
+	startupError     *StartupError
 	views            map[string]View
 	cfg              *config.Config
+	file             *config.File
 	vault            vault.VaultSvc
 	main             *tview.Flex
+	commandBar       *CommandBar
+	modalFocus       tview.Primitive
+	// configuration used before context switch, restored when the switch is cancelled
+	prevCfg *config.Config
 }
 
 func NewTui() *Tui {
@@ -25,11 +36,13 @@ func NewTui() *Tui {
 		App:   tview.NewApplication(),
 		pages: tview.NewPages(),
 		cfg:   config.NewConfig(),
+		file:  &config.File{},
 		views: make(map[string]View),
 	}
 
 	//modal
 	tui.vaultConfigModal = NewModalInput(tui)
+	tui.startupError = NewStartupError(tui)
 
 	//header
 	header := NewHeaderView(tui)
@@ -38,39 +51,113 @@ func NewTui() *Tui {
 	secretEngine := NewSecretEngineView(tui)
 	secretData := NewSecretDataView(tui)
 	secrets := NewSecretView(tui)
+	secretVersions := NewSecretVersionsView(tui)
+	secretConflict := NewSecretConflictView(tui)
+	contexts := NewContextView(tui)
+	namespaces := NewNamespaceView(tui)
+	policies := NewPolicyView(tui)
+	authMethods := NewAuthView(tui)
+	search := NewSearchView(tui)
+	grep := NewGrepView(tui)
+	tree := NewTreeView(tui)
 
 	tui.pages.AddPage(constants.ViewSecretEngines, secretEngine, true, true)
 	tui.pages.AddPage(constants.ViewSecrets, secrets, true, false)
 	tui.pages.AddPage(constants.ViewSecretData, secretData, true, false)
+	tui.pages.AddPage(constants.ViewSecretVersions, secretVersions, true, false)
+	tui.pages.AddPage(constants.ViewSecretConflict, secretConflict, true, false)
+	tui.pages.AddPage(constants.ViewContexts, contexts, true, false)
+	tui.pages.AddPage(constants.ViewNamespaces, namespaces, true, false)
+	tui.pages.AddPage(constants.ViewPolicies, policies, true, false)
+	tui.pages.AddPage(constants.ViewAuthMethods, authMethods, true, false)
+	tui.pages.AddPage(constants.ViewSearch, search, true, false)
+	tui.pages.AddPage(constants.ViewGrep, grep, true, false)
+	tui.pages.AddPage(constants.ViewTree, tree, true, false)
 
 	tui.views[constants.ViewHeader] = header
 	tui.views[constants.ViewSecrets] = secrets
 	tui.views[constants.ViewSecretData] = secretData
 	tui.views[constants.ViewSecretEngines] = secretEngine
+	tui.views[constants.ViewSecretVersions] = secretVersions
+	tui.views[constants.ViewSecretConflict] = secretConflict
+	tui.views[constants.ViewContexts] = contexts
+	tui.views[constants.ViewNamespaces] = namespaces
+	tui.views[constants.ViewPolicies] = policies
+	tui.views[constants.ViewAuthMethods] = authMethods
+	tui.views[constants.ViewSearch] = search
+	tui.views[constants.ViewGrep] = grep
+	tui.views[constants.ViewTree] = tree
+
+	// command bar is hidden (zero height) until ':' is pressed
+	tui.commandBar = NewCommandBar(tui)
 
 	tui.main = tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(header, 7, 0, false).
+		AddItem(tui.commandBar, 0, 0, false).
 		AddItem(tui.pages, 0, 1, true)
 
+	tui.defineEvents()
+
 	return tui
 }
 
-func (tui *Tui) Init() {
-	if os.Getenv("VAULT_ADDR") == "" || os.Getenv("VAULT_TOKEN") == "" {
-		tui.App.SetRoot(tui.vaultConfigModal, true).EnableMouse(false)
-		tui.vaultConfigModal.Init()
-	} else {
+// Init configures vault from the context (--context flag or current context from config file)
+// or from VAULT_ADDR, token is resolved like vault cli does it (VAULT_TOKEN, token helper, ~/.vault-token),
+// configuration modal is shown if address or token is missing
+func (tui *Tui) Init(contextName string) {
+	file, err := config.LoadFile()
+	tui.file = file
+	if err != nil {
+		tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+	}
+	if contextName == "" && os.Getenv("VAULT_ADDR") == "" {
+		contextName = file.CurrentContext
+	}
+	if contextName != "" {
+		if ctx, ok := file.Context(contextName); ok {
+			tui.cfg.UseContext(ctx)
+		} else {
+			tui.ShowStatusAndContinue(fmt.Sprintf("context '%s' does not exist", contextName), ErrStatus)
+		}
+	}
+	if tui.cfg.VaultAddr == "" {
 		tui.cfg.UpdateVaultAddr(os.Getenv("VAULT_ADDR"))
-		tui.InitVault(tui.cfg.VaultAddr, os.Getenv("VAULT_TOKEN"))
-		err := tui.InitMain()
-		if err != nil {
-			tui.ShowErrAndStop(err)
+	}
+	if tui.cfg.Namespace == "" {
+		tui.cfg.UpdateNamespace(os.Getenv("VAULT_NAMESPACE"))
+	}
+	tui.cfg.UpdateTLSFromEnv()
+
+	token, source, err := config.ResolveToken()
+	if err != nil {
+		tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+	}
+	tui.cfg.TokenSource = source
+
+	// contexts with auth method other than token always ask for credentials
+	loginRequired := tui.cfg.AuthMethod != "" && tui.cfg.AuthMethod != vault.AuthToken
+	if tui.cfg.VaultAddr == "" || token == "" || loginRequired {
+		tui.ShowConfigModal()
+	} else {
+		if err := tui.InitVault(tui.cfg.VaultAddr, token); err != nil {
+			tui.ShowStartupError(err)
+			return
 		}
+		tui.RetryMain()
 	}
 }
 
+func (tui *Tui) ShowConfigModal() {
+	tui.App.SetRoot(tui.vaultConfigModal, true).EnableMouse(false)
+	tui.vaultConfigModal.Init()
+}
+
 func (tui *Tui) InitMain() error {
+	if tui.vault == nil {
+		return &models.StepError{Step: models.StepConnect, Err: errors.New("vault is not configured")}
+	}
 	tui.App.SetRoot(tui.main, true).EnableMouse(false)
+	tui.TogglePage(constants.ViewSecretEngines)
 	err := tui.views[constants.ViewHeader].Hydrate()
 	if err != nil {
 		return err
@@ -78,11 +165,227 @@ func (tui *Tui) InitMain() error {
 
 	err = tui.views[constants.ViewSecretEngines].Hydrate()
 	if err != nil {
+		return &models.StepError{Step: models.StepSecretList, Err: err}
+	}
+	if tui.cfg.DefaultEngine != "" {
+		tui.ShowSecretsView(tui.cfg.DefaultEngine)
+	}
+	return nil
+}
+
+// RetryMain initializes main view, startup error screen is shown on failure,
+// switched context is saved only when main view is initialized (vault is reached)
+func (tui *Tui) RetryMain() {
+	if err := tui.InitMain(); err != nil {
+		if tui.prevCfg != nil {
+			// switch failed, previous context is configured again (its token is asked for)
+			tui.restoreContext()
+			tui.Connect(nil)
+		}
+		tui.ShowStartupError(err)
+		return
+	}
+	tui.commitContext()
+}
+
+// ShowStartupError shows recoverable error screen with retry and configuration
+func (tui *Tui) ShowStartupError(err error) {
+	tui.App.SetRoot(tui.startupError, true)
+	tui.startupError.Show(err)
+}
+
+// defineEvents defines global shortcuts, available only when main view is shown
+func (tui *Tui) defineEvents() {
+	tui.App.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if !tui.main.HasFocus() || tui.pages.HasPage(constants.ModalPage) {
+			return event
+		}
+		if event.Key() == tcell.KeyCtrlT {
+			tui.ShowContextsView()
+			return nil
+		} else if event.Key() == tcell.KeyCtrlN {
+			tui.ShowNamespacesView()
+			return nil
+		} else if event.Key() == tcell.KeyCtrlG {
+			tui.ShowConfigModal()
+			return nil
+		} else if event.Rune() == ':' && !tui.isEditing() {
+			tui.commandBar.Show()
+			return nil
+		}
+		return event
+	})
+}
+
+// isEditing reports whether text is typed into focused input (':' is not a command then)
+func (tui *Tui) isEditing() bool {
+	switch tui.App.GetFocus().(type) {
+	case *tview.InputField, *tview.TextArea, *CommandBar:
+		return true
+	}
+	return false
+}
+
+// ShowSearchView searches pattern in secret paths below the current path of the secrets view,
+// empty pattern shows results of the last search
+func (tui *Tui) ShowSearchView(pattern string) error {
+	if pattern == "" {
+		if !tui.views[constants.ViewSearch].(SearchViewI).HasResults() {
+			return errors.New("search pattern is missing")
+		}
+		tui.TogglePage(constants.ViewSearch)
+		return nil
+	}
+	engine, p := tui.views[constants.ViewSecrets].(SecretViewI).Location()
+	if engine == "" {
+		return errors.New("open secret engine to search in")
+	}
+	if err := tui.views[constants.ViewSearch].Hydrate(engine, p, pattern); err != nil {
+		return err
+	}
+	tui.TogglePage(constants.ViewSearch)
+	return nil
+}
+
+// ShowGrepView greps secrets below the current path of the secrets view, key names and values are matched
+// unless the pattern is prefixed with -k (keys only) or -v (values only), empty pattern shows results of the last grep
+func (tui *Tui) ShowGrepView(arg string) error {
+	scope := models.GrepAll
+	if flag, pattern, ok := strings.Cut(arg, " "); ok && (flag == "-k" || flag == "-v") {
+		scope = models.GrepKeys
+		if flag == "-v" {
+			scope = models.GrepValues
+		}
+		arg = strings.TrimSpace(pattern)
+	}
+	if arg == "" {
+		if !tui.views[constants.ViewGrep].(GrepViewI).HasResults() {
+			return errors.New("grep pattern is missing")
+		}
+		tui.TogglePage(constants.ViewGrep)
+		return nil
+	}
+	engine, p := tui.views[constants.ViewSecrets].(SecretViewI).Location()
+	if engine == "" {
+		return errors.New("open secret engine to grep in")
+	}
+	if err := tui.views[constants.ViewGrep].Hydrate(engine, p, arg, scope); err != nil {
+		return err
+	}
+	tui.TogglePage(constants.ViewGrep)
+	return nil
+}
+
+// ShowTreeView shows tree of the engine (the current engine of the secrets view by default)
+// with the current path expanded
+func (tui *Tui) ShowTreeView(engine string) error {
+	p := ""
+	if engine == "" {
+		engine, p = tui.views[constants.ViewSecrets].(SecretViewI).Location()
+	} else {
+		e, ep, ok := tui.splitEnginePath(strings.TrimPrefix(engine, "/"))
+		if !ok {
+			return fmt.Errorf("unknown secret engine in '%s'", engine)
+		}
+		engine, p = e, ep
+	}
+	if engine == "" {
+		return errors.New("open secret engine to show its tree")
+	}
+	if err := tui.views[constants.ViewTree].Hydrate(engine, p); err != nil {
+		return err
+	}
+	tui.TogglePage(constants.ViewTree)
+	return nil
+}
+
+// ShowFilter opens filter prompt of the list
+func (tui *Tui) ShowFilter(l *List) {
+	tui.commandBar.ShowFilter(l)
+}
+
+func (tui *Tui) ShowEnginesView() error {
+	if err := tui.views[constants.ViewSecretEngines].Hydrate(); err != nil {
+		return err
+	}
+	tui.TogglePage(constants.ViewSecretEngines)
+	return nil
+}
+
+func (tui *Tui) ShowPoliciesView() error {
+	if err := tui.views[constants.ViewPolicies].Hydrate(); err != nil {
+		return err
+	}
+	tui.TogglePage(constants.ViewPolicies)
+	return nil
+}
+
+func (tui *Tui) ShowAuthMethodsView() error {
+	if err := tui.views[constants.ViewAuthMethods].Hydrate(); err != nil {
 		return err
 	}
+	tui.TogglePage(constants.ViewAuthMethods)
 	return nil
 }
 
+func (tui *Tui) ShowContextsView() {
+	tui.views[constants.ViewContexts].Hydrate()
+	tui.TogglePage(constants.ViewContexts)
+}
+
+// SwitchContext asks for the token of the context, context is made current (and saved to config file)
+// only after main view is initialized, previous configuration is restored when the dialog is cancelled
+// or vault cannot be reached
+func (tui *Tui) SwitchContext(ctx config.Context) {
+	if tui.prevCfg == nil {
+		prev := *tui.cfg
+		tui.prevCfg = &prev
+	}
+	tui.cfg.UseContext(ctx)
+	tui.cfg.UpdateTLSFromEnv()
+	tui.ShowConfigModal()
+}
+
+// commitContext saves context of the configuration as current once vault is reached
+func (tui *Tui) commitContext() {
+	if tui.prevCfg == nil {
+		return
+	}
+	tui.prevCfg = nil
+	tui.file.CurrentContext = tui.cfg.Context
+	if err := tui.file.Save(); err != nil {
+		tui.ShowStatusAndContinue(fmt.Sprintf("context is not saved: %v", err), ErrStatus)
+	}
+}
+
+// restoreContext returns configuration used before the context switch
+func (tui *Tui) restoreContext() {
+	if tui.prevCfg == nil {
+		return
+	}
+	*tui.cfg = *tui.prevCfg
+	tui.prevCfg = nil
+}
+
+func (tui *Tui) ShowNamespacesView() {
+	if err := tui.views[constants.ViewNamespaces].Hydrate(); err != nil {
+		tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	tui.TogglePage(constants.ViewNamespaces)
+}
+
+// SwitchNamespace moves vault client to the namespace, cached secrets are dropped and views are hydrated again
+func (tui *Tui) SwitchNamespace(namespace string) {
+	if err := tui.vault.SetNamespace(namespace); err != nil {
+		tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	tui.cfg.UpdateNamespace(namespace)
+	tui.clearViews()
+	tui.RetryMain()
+}
+
 func (tui *Tui) PublishInfo(msg string) {
 	tui.views[constants.ViewHeader].(HeaderViewI).Info(msg)
 }
@@ -91,20 +394,20 @@ func (tui *Tui) PublishErr(msg string) {
 	tui.views[constants.ViewHeader].(HeaderViewI).Err(msg)
 }
 
+func (tui *Tui) PublishWarn(msg string) {
+	tui.views[constants.ViewHeader].(HeaderViewI).Warn(msg)
+}
+
 func (tui *Tui) PublishSuccess(msg string) {
 	tui.views[constants.ViewHeader].(HeaderViewI).Success(msg)
 }
 
-func (tui *Tui) ClearStatus() {
-	tui.views[constants.ViewHeader].(HeaderViewI).Reset()
+func (tui *Tui) PublishProgress(msg string) {
+	tui.views[constants.ViewHeader].(HeaderViewI).Progress(msg)
 }
 
-func (tui *Tui) ShowErrAndStop(err error) {
-	go func() {
-		tui.PublishErr(err.Error())
-		time.Sleep(time.Second * 5)
-		tui.App.Stop()
-	}()
+func (tui *Tui) ClearStatus() {
+	tui.views[constants.ViewHeader].(HeaderViewI).Reset()
 }
 
 func (tui *Tui) ShowStatusAndContinue(msg string, statusType StatusType) {
@@ -115,6 +418,8 @@ func (tui *Tui) ShowStatusAndContinue(msg string, statusType StatusType) {
 		tui.PublishInfo(msg)
 	case SuccessStatus:
 		tui.PublishSuccess(msg)
+	case WarnStatus:
+		tui.PublishWarn(msg)
 	}
 	go func() {
 		time.Sleep(time.Second * 5)
@@ -131,6 +436,22 @@ func (tui *Tui) TogglePage(name string) {
 	tui.pages.ShowPage(name)
 }
 
+// ShowModal shows primitive on top of the current page
+func (tui *Tui) ShowModal(p tview.Primitive) {
+	tui.modalFocus = tui.App.GetFocus()
+	tui.pages.AddPage(constants.ModalPage, p, true, true)
+	tui.App.SetFocus(p)
+}
+
+// HideModal removes modal and returns focus to the primitive focused before the modal
+func (tui *Tui) HideModal() {
+	tui.pages.RemovePage(constants.ModalPage)
+	if tui.modalFocus != nil {
+		tui.App.SetFocus(tui.modalFocus)
+		tui.modalFocus = nil
+	}
+}
+
 func (tui *Tui) TogglePageAndRefresh(name string) {
 	//extend this to work with all interfaces (extend View interface)
 	tui.views[name].(SecretViewI).SecretsHardRefresh()
@@ -139,7 +460,10 @@ func (tui *Tui) TogglePageAndRefresh(name string) {
 
 func (tui *Tui) ShowSecretsView(engine string) {
 	tui.TogglePage(constants.ViewSecrets)
-	tui.views[constants.ViewSecrets].Hydrate(engine)
+	if err := tui.views[constants.ViewSecrets].Hydrate(engine); err != nil {
+		tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		tui.TogglePage(constants.ViewSecretEngines)
+	}
 }
 
 func (tui *Tui) ShowSecretDataView(secret, engine string) {
@@ -147,14 +471,76 @@ func (tui *Tui) ShowSecretDataView(secret, engine string) {
 	tui.views[constants.ViewSecretData].Hydrate(secret, engine)
 }
 
-func (tui *Tui) InitVault(addr, token string) {
-	var err error
-	tui.vault, err = vault.NewVault(addr, token)
+// ShowSecretDataVersionView opens given version of the secret in read-only mode
+func (tui *Tui) ShowSecretDataVersionView(secret, engine string, version int) {
+	tui.TogglePage(constants.ViewSecretData)
+	tui.views[constants.ViewSecretData].Hydrate(secret, engine, version)
+}
+
+func (tui *Tui) ShowSecretDiffView(secret, engine string, from, to int) {
+	if err := tui.views[constants.ViewSecretData].(SecretDataViewI).HydrateDiff(secret, engine, from, to); err != nil {
+		tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	tui.TogglePage(constants.ViewSecretData)
+}
+
+func (tui *Tui) ShowSecretVersionsView(secret, engine string) {
+	if err := tui.views[constants.ViewSecretVersions].Hydrate(secret, engine); err != nil {
+		tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	tui.TogglePage(constants.ViewSecretVersions)
+}
+
+// ShowSecretConflictView compares my edits (mine) with version they are based on (base) and current remote version
+func (tui *Tui) ShowSecretConflictView(secret, engine string, base, mine map[string]vault.SecretValue, baseVersion int) {
+	if err := tui.views[constants.ViewSecretConflict].Hydrate(secret, engine, base, mine, baseVersion); err != nil {
+		tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	tui.TogglePage(constants.ViewSecretConflict)
+}
+
+func (tui *Tui) InitVault(addr, token string) error {
+	svc, err := vault.NewVault(addr, token, tui.cfg.Namespace, tui.cfg.TLS)
 	if err != nil {
+		return &models.StepError{Step: models.StepConnect, Err: err}
+	}
+	tui.Connect(svc)
+	return nil
+}
+
+// Connect replaces current vault connection, the old one is closed and state of all views is cleared
+func (tui *Tui) Connect(svc vault.VaultSvc) {
+	if tui.vault != nil {
+		tui.vault.Close()
+	}
+	tui.clearViews()
+	tui.vault = svc
+}
 
+func (tui *Tui) clearViews() {
+	for _, v := range tui.views {
+		if sv, ok := v.(StatefulView); ok {
+			sv.ClearState()
+		}
 	}
 }
 
+// LoginVault connects to vault and logs in with the auth method, token is kept only in memory
+func (tui *Tui) LoginVault(addr, method, mount string, creds vault.Credentials) error {
+	svc, err := vault.NewVault(addr, "", tui.cfg.Namespace, tui.cfg.TLS)
+	if err != nil {
+		return err
+	}
+	if err := svc.Login(method, mount, creds); err != nil {
+		return err
+	}
+	tui.Connect(svc)
+	return nil
+}
+
 func (a *Tui) QueueUpdateDraw(f func()) {
 	if a.App == nil {
 		return
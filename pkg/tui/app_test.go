@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"net"
+	"testing"
+	"vaultview/pkg/config"
+)
+
+// TestClearViewsFresh clears state of views which were never hydrated, as connect does on startup
+func TestClearViewsFresh(t *testing.T) {
+	tui := NewTui()
+	tui.clearViews()
+	tui.clearViews()
+}
+
+// TestSwitchContextUnreachable keeps previous context when vault of the new one cannot be reached
+func TestSwitchContextUnreachable(t *testing.T) {
+	// address nobody listens on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := "http://" + l.Addr().String()
+	l.Close()
+
+	tui := NewTui()
+	tui.cfg.UseContext(config.Context{Name: "dev", Address: "http://dev:8200"})
+	tui.file = &config.File{CurrentContext: "dev"}
+
+	tui.SwitchContext(config.Context{Name: "prod", Address: addr})
+	if err := tui.InitVault(tui.cfg.VaultAddr, "token"); err != nil {
+		t.Fatal(err)
+	}
+	tui.RetryMain()
+
+	if tui.file.CurrentContext != "dev" {
+		t.Errorf("unreachable context is saved as current: %s", tui.file.CurrentContext)
+	}
+	if tui.cfg.Context != "dev" || tui.cfg.VaultAddr != "http://dev:8200" {
+		t.Errorf("previous context is not restored: %s (%s)", tui.cfg.Context, tui.cfg.VaultAddr)
+	}
+	if tui.vault != nil {
+		t.Error("connection of the unreachable context is kept")
+	}
+}
@@ -0,0 +1,377 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"vaultview/pkg/constants"
+	"vaultview/pkg/utils"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const commandHistorySize = 50
+
+type command struct {
+	name     string
+	aliases  []string
+	help     string
+	run      func(tui *Tui, arg string) error
+	complete func(tui *Tui, arg string) []string
+}
+
+var commands = []command{
+	{name: "engines", aliases: []string{"se"}, help: "list secret engines", run: func(tui *Tui, arg string) error {
+		return tui.ShowEnginesView()
+	}},
+	{name: "cd", help: "open <engine>/<path> (folder or secret)", run: func(tui *Tui, arg string) error {
+		return tui.ChangePath(arg)
+	}, complete: completePath},
+	{name: "search", aliases: []string{"find"}, help: "search secret paths below the current path, without pattern shows the last results", run: func(tui *Tui, arg string) error {
+		return tui.ShowSearchView(arg)
+	}},
+	{name: "grep", help: "grep [-k|-v] <pattern> searches key names and values of secrets below the current path, without pattern shows the last results", run: func(tui *Tui, arg string) error {
+		return tui.ShowGrepView(arg)
+	}},
+	{name: "tree", help: "tree [<engine>/<path>] shows engine hierarchy with secret preview", run: func(tui *Tui, arg string) error {
+		return tui.ShowTreeView(arg)
+	}, complete: completePath},
+	{name: "policies", aliases: []string{"pol"}, help: "list acl policies", run: func(tui *Tui, arg string) error {
+		return tui.ShowPoliciesView()
+	}},
+	{name: "auth", help: "list auth methods", run: func(tui *Tui, arg string) error {
+		return tui.ShowAuthMethodsView()
+	}},
+	{name: "ctx", aliases: []string{"context"}, help: "context picker, ctx <name> switches context", run: func(tui *Tui, arg string) error {
+		if arg == "" {
+			tui.ShowContextsView()
+			return nil
+		}
+		ctx, ok := tui.file.Context(arg)
+		if !ok {
+			return fmt.Errorf("context '%s' does not exist", arg)
+		}
+		tui.SwitchContext(ctx)
+		return nil
+	}, complete: completeContext},
+	{name: "ns", aliases: []string{"namespaces"}, help: "namespace browser", run: func(tui *Tui, arg string) error {
+		tui.ShowNamespacesView()
+		return nil
+	}},
+	{name: "reconnect", help: "open configuration dialog", run: func(tui *Tui, arg string) error {
+		tui.ShowConfigModal()
+		return nil
+	}},
+	{name: "q", aliases: []string{"quit", "q!"}, help: "quit vaultview", run: func(tui *Tui, arg string) error {
+		tui.App.Stop()
+		return nil
+	}},
+}
+
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name || slices.Contains(c.aliases, name) {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// CommandBar is ':' prompt with history (Up/Down) and completion (Tab),
+// the same bar is used as '/' prompt filtering the list
+type CommandBar struct {
+	*tview.InputField
+	tui     *Tui
+	history []string
+	// position in history while browsing it, len(history) is the new command
+	histPos int
+	focus   tview.Primitive
+	// list filtered by the prompt, nil in command mode
+	filter *List
+	regex  bool
+}
+
+func NewCommandBar(tui *Tui) *CommandBar {
+	cb := &CommandBar{
+		InputField: tview.NewInputField(),
+		tui:        tui,
+	}
+	cb.SetFieldBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+	cb.SetBorder(true)
+	cb.SetChangedFunc(func(text string) {
+		if cb.filter != nil {
+			cb.applyFilter()
+		}
+	})
+	cb.SetDoneFunc(func(key tcell.Key) {
+		switch {
+		case cb.filter != nil && key == tcell.KeyEscape:
+			// clearing the filter keeps the current item selected
+			cb.filter.SetFilter("", false)
+			cb.Hide()
+		case cb.filter != nil:
+			cb.Hide()
+		case key == tcell.KeyEnter:
+			cb.execute(cb.GetText())
+		case key == tcell.KeyEscape:
+			cb.Hide()
+		}
+	})
+	cb.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if cb.filter != nil {
+			if event.Key() == tcell.KeyTab {
+				cb.regex = !cb.regex
+				cb.applyFilter()
+				return nil
+			}
+			return event
+		}
+		switch event.Key() {
+		case tcell.KeyUp:
+			cb.browseHistory(-1)
+			return nil
+		case tcell.KeyDown:
+			cb.browseHistory(1)
+			return nil
+		case tcell.KeyTab:
+			cb.complete()
+			return nil
+		}
+		return event
+	})
+	return cb
+}
+
+// Show opens the prompt, focus is returned to the current primitive when prompt is closed
+func (cb *CommandBar) Show() {
+	cb.Prompt("")
+}
+
+// Prompt opens the prompt prefilled with the (partial) command
+func (cb *CommandBar) Prompt(text string) {
+	cb.filter = nil
+	cb.SetLabel(colorfulPrint(":", tcell.ColorLime))
+	cb.histPos = len(cb.history)
+	cb.SetText(text)
+	cb.open()
+}
+
+// ShowFilter opens prompt filtering the list while typing, Tab switches fuzzy and regex matching,
+// Enter keeps the filter and Esc clears it
+func (cb *CommandBar) ShowFilter(l *List) {
+	pattern, regex := l.Filter()
+	cb.filter = nil
+	cb.regex = regex
+	cb.SetText(pattern)
+	cb.filter = l
+	cb.setFilterLabel()
+	cb.open()
+}
+
+func (cb *CommandBar) open() {
+	cb.focus = cb.tui.App.GetFocus()
+	cb.tui.main.ResizeItem(cb, 3, 0)
+	cb.tui.App.SetFocus(cb)
+}
+
+func (cb *CommandBar) Hide() {
+	cb.filter = nil
+	cb.tui.main.ResizeItem(cb, 0, 0)
+	if cb.focus != nil {
+		cb.tui.App.SetFocus(cb.focus)
+		cb.focus = nil
+	}
+}
+
+func (cb *CommandBar) applyFilter() {
+	cb.setFilterLabel()
+	cb.SetFieldTextColor(tview.Styles.PrimaryTextColor)
+	if err := cb.filter.SetFilter(cb.GetText(), cb.regex); err != nil {
+		// pattern is not valid regular expression (yet), list keeps the previous filter
+		cb.SetFieldTextColor(tcell.ColorRed)
+	}
+}
+
+func (cb *CommandBar) setFilterLabel() {
+	mode := "fuzzy"
+	if cb.regex {
+		mode = "regex"
+	}
+	cb.SetLabel(colorfulPrint(fmt.Sprintf("/%s ", mode), tcell.ColorLime))
+}
+
+func (cb *CommandBar) execute(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		cb.Hide()
+		return
+	}
+	cb.addHistory(line)
+	name, arg, _ := strings.Cut(line, " ")
+	c, ok := findCommand(name)
+	if !ok {
+		cb.tui.ShowStatusAndContinue(fmt.Sprintf("unknown command '%s', available: %s", name, commandNames()), ErrStatus)
+		return
+	}
+	// command may move focus (new page, dialog), prompt must not restore the old one
+	cb.tui.main.ResizeItem(cb, 0, 0)
+	cb.focus = nil
+	cb.tui.App.SetFocus(cb.tui.pages)
+	if err := c.run(cb.tui, strings.TrimSpace(arg)); err != nil {
+		cb.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+	}
+}
+
+func (cb *CommandBar) addHistory(line string) {
+	cb.history = slices.DeleteFunc(cb.history, func(h string) bool {
+		return h == line
+	})
+	cb.history = append(cb.history, line)
+	if len(cb.history) > commandHistorySize {
+		cb.history = cb.history[len(cb.history)-commandHistorySize:]
+	}
+}
+
+func (cb *CommandBar) browseHistory(step int) {
+	pos := cb.histPos + step
+	if pos < 0 || pos > len(cb.history) {
+		return
+	}
+	cb.histPos = pos
+	if pos == len(cb.history) {
+		cb.SetText("")
+		return
+	}
+	cb.SetText(cb.history[pos])
+}
+
+// complete completes command name or its argument to the longest common prefix,
+// ambiguous candidates are shown in the status
+func (cb *CommandBar) complete() {
+	text := cb.GetText()
+	name, arg, hasArg := strings.Cut(text, " ")
+	var prefix string
+	var candidates []string
+	if !hasArg {
+		for _, c := range commands {
+			if strings.HasPrefix(c.name, name) {
+				candidates = append(candidates, c.name+" ")
+			}
+		}
+	} else if c, ok := findCommand(name); ok && c.complete != nil {
+		prefix = name + " "
+		candidates = c.complete(cb.tui, strings.TrimLeft(arg, " "))
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	cb.SetText(prefix + commonPrefix(candidates))
+	if len(candidates) > 1 {
+		cb.tui.ShowStatusAndContinue(strings.Join(candidates, " "), InfoStatus)
+	}
+}
+
+func completeContext(tui *Tui, arg string) []string {
+	var names []string
+	for _, ctx := range tui.file.Contexts {
+		if strings.HasPrefix(ctx.Name, arg) {
+			names = append(names, ctx.Name)
+		}
+	}
+	return names
+}
+
+// completePath completes engine names and then secret paths (folders are listed lazily and cached)
+func completePath(tui *Tui, arg string) []string {
+	arg = strings.TrimPrefix(arg, "/")
+	engine, p, ok := tui.splitEnginePath(arg)
+	if !ok || (p == "" && !strings.HasSuffix(arg, "/")) {
+		var names []string
+		for _, e := range tui.views[constants.ViewSecretEngines].(SecretEngineViewI).Engines() {
+			if e.IsKv() && strings.HasPrefix(e.Name+"/", arg) {
+				names = append(names, e.Name+"/")
+			}
+		}
+		return names
+	}
+	dir := ""
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		dir = p[:i+1]
+	}
+	secrets, err := tui.views[constants.ViewSecrets].(SecretViewI).CachedList(engine, dir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, s := range secrets {
+		if strings.HasPrefix(dir+s, p) {
+			paths = append(paths, engine+"/"+dir+s)
+		}
+	}
+	return paths
+}
+
+// splitEnginePath splits engine/path using the longest matching engine name (engine names may contain '/')
+func (tui *Tui) splitEnginePath(target string) (string, string, bool) {
+	engine := ""
+	for _, e := range tui.views[constants.ViewSecretEngines].(SecretEngineViewI).Engines() {
+		if (target == e.Name || strings.HasPrefix(target, e.Name+"/")) && len(e.Name) > len(engine) {
+			engine = e.Name
+		}
+	}
+	if engine == "" {
+		return "", "", false
+	}
+	return engine, strings.TrimPrefix(strings.TrimPrefix(target, engine), "/"), true
+}
+
+// ChangePath opens folder (path ending with '/') or secret of the engine
+func (tui *Tui) ChangePath(target string) error {
+	target = strings.TrimPrefix(target, "/")
+	engine, p, ok := tui.splitEnginePath(target)
+	if !ok {
+		return fmt.Errorf("unknown secret engine in '%s'", target)
+	}
+	secretView := tui.views[constants.ViewSecrets].(SecretViewI)
+	if p == "" || strings.HasSuffix(p, "/") {
+		if _, err := secretView.HydratePath(engine, p); err != nil {
+			return err
+		}
+		tui.TogglePage(constants.ViewSecrets)
+		return nil
+	}
+	parent := utils.GetParentPath(p)
+	secrets, err := secretView.HydratePath(engine, parent)
+	if err != nil {
+		return err
+	}
+	child := utils.GetChildPath(p)
+	if slices.Contains(secrets, child+"/") {
+		return tui.ChangePath(target + "/")
+	}
+	if !slices.Contains(secrets, child) {
+		return fmt.Errorf("secret '%s' does not exist in '%s'", p, engine)
+	}
+	tui.TogglePage(constants.ViewSecrets)
+	tui.ShowSecretDataView(p, engine)
+	return nil
+}
+
+func commandNames() string {
+	names := make([]string, 0, len(commands))
+	for _, c := range commands {
+		names = append(names, c.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func commonPrefix(items []string) string {
+	prefix := items[0]
+	for _, item := range items[1:] {
+		for !strings.HasPrefix(item, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
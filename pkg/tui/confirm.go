@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	confirmYes = "Yes"
+	confirmNo  = "No"
+)
+
+// ShowConfirm shows modal on top of the current page, confirmed is called only if user selects 'Yes'
+func (tui *Tui) ShowConfirm(msg string, confirmed func()) {
+	modal := tview.NewModal().
+		SetText(msg).
+		AddButtons([]string{confirmYes, confirmNo}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			tui.HideModal()
+			if buttonLabel == confirmYes {
+				confirmed()
+			}
+		})
+	tui.ShowModal(modal)
+}
+
+// ShowTypedConfirm shows modal which requires user to type expected text,
+// used for destructive actions
+func (tui *Tui) ShowTypedConfirm(title, msg, expected string, confirmed func()) {
+	typed := ""
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf(" [%s::]%s ", tcell.ColorWhite, title))
+	form.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetFieldBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+	form.SetCancelFunc(tui.HideModal)
+	form.AddTextView("", msg, 0, 4, true, false)
+	form.AddInputField(colorfulPrint(fmt.Sprintf("Type '%s' to confirm: ", expected), tcell.ColorLime), "", 0, nil, func(text string) {
+		typed = text
+	})
+	form.AddButton("Confirm", func() {
+		if typed != expected {
+			form.SetTitle(fmt.Sprintf(" [%s::]%s ", tcell.ColorRed, "Confirmation text does not match"))
+			return
+		}
+		tui.HideModal()
+		confirmed()
+	})
+	form.AddButton("Cancel", tui.HideModal)
+	form.SetFocus(1)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 80, 1, true).
+			AddItem(nil, 0, 1, false), 13, 1, true).
+		AddItem(nil, 0, 1, false)
+	tui.ShowModal(layout)
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"vaultview/pkg/constants"
+	"vaultview/pkg/vault"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type diffChange int
+
+const (
+	diffUnchanged diffChange = iota
+	diffAdded
+	diffRemoved
+	diffChanged
+)
+
+type keyDiff struct {
+	key      string
+	change   diffChange
+	fromHash string
+	toHash   string
+}
+
+// diffSecrets compares secrets key by key, change is detected by hash of the value
+func diffSecrets(from, to map[string]vault.SecretValue) []keyDiff {
+	keys := make(map[string]struct{})
+	for k := range from {
+		keys[k] = struct{}{}
+	}
+	for k := range to {
+		keys[k] = struct{}{}
+	}
+	diffs := []keyDiff{}
+	for k := range keys {
+		d := keyDiff{key: k}
+		fromVal, inFrom := from[k]
+		toVal, inTo := to[k]
+		if inFrom {
+			d.fromHash = getValueHash(fromVal)
+		}
+		if inTo {
+			d.toHash = getValueHash(toVal)
+		}
+		switch {
+		case !inFrom:
+			d.change = diffAdded
+		case !inTo:
+			d.change = diffRemoved
+		case !fromVal.Equal(toVal):
+			d.change = diffChanged
+		default:
+			d.change = diffUnchanged
+		}
+		diffs = append(diffs, d)
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].key < diffs[j].key
+	})
+	return diffs
+}
+
+func (d keyDiff) String() string {
+	switch d.change {
+	case diffAdded:
+		return colorfulPrint(fmt.Sprintf("+ added (%s)", shortHash(d.toHash)), tcell.ColorGreen)
+	case diffRemoved:
+		return colorfulPrint(fmt.Sprintf("- removed (%s)", shortHash(d.fromHash)), tcell.ColorDarkRed)
+	case diffChanged:
+		return colorfulPrint(fmt.Sprintf("~ changed (%s -> %s)", shortHash(d.fromHash), shortHash(d.toHash)), tcell.ColorDarkOrange)
+	}
+	return colorfulPrint(fmt.Sprintf("= unchanged (%s)", shortHash(d.toHash)), tcell.ColorGray)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+// diffPaneText is value of one side of the diff, lines missing on the other side are colored
+func diffPaneText(v vault.SecretValue, ok bool, other vault.SecretValue, otherOk bool, color tcell.Color) string {
+	if !ok {
+		return constants.NAValue
+	}
+	otherLines := make(map[string]bool)
+	if otherOk {
+		for _, line := range strings.Split(other.Text, "\n") {
+			otherLines[line] = true
+		}
+	}
+	lines := []string{fmt.Sprintf("(%s)", v.Type)}
+	for _, line := range strings.Split(v.Text, "\n") {
+		if otherLines[line] {
+			lines = append(lines, tview.Escape(line))
+		} else {
+			lines = append(lines, colorfulPrint(tview.Escape(line), color))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
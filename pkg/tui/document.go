@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"vaultview/pkg/vault"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// documentEditor edits whole secret as json or yaml document,
+// document is validated on save and parse errors are shown under the editor
+type documentEditor struct {
+	*tview.Flex
+	editor *tview.TextArea
+	errors *tview.TextView
+	format vault.DocumentFormat
+	save   func(secret map[string]vault.SecretValue)
+	close  func()
+}
+
+func newDocumentEditor(save func(secret map[string]vault.SecretValue), close func()) *documentEditor {
+	de := &documentEditor{
+		Flex:   tview.NewFlex(),
+		editor: tview.NewTextArea(),
+		errors: tview.NewTextView(),
+		save:   save,
+		close:  close,
+	}
+
+	de.editor.SetBorder(true)
+	de.editor.SetBorderColor(tcell.ColorLime)
+	de.editor.SetBorderAttributes(tcell.AttrBold)
+	de.editor.SetWrap(false)
+	de.editor.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			de.close()
+			return nil
+		} else if event.Key() == tcell.KeyCtrlS {
+			de.submit()
+			return nil
+		}
+		return event
+	})
+
+	de.errors.SetDynamicColors(true)
+	de.errors.SetWrap(true)
+	de.errors.SetBorder(true)
+	de.errors.SetTitle(" [[::b]Errors[::-]] ")
+
+	de.SetDirection(tview.FlexRow)
+	de.AddItem(de.editor, 0, 1, true)
+	de.AddItem(de.errors, 0, 0, false)
+	return de
+}
+
+func (de *documentEditor) open(doc string, format vault.DocumentFormat) {
+	de.format = format
+	de.editor.SetTitle(fmt.Sprintf(" [[::b]Edit Mode[::-] (%s document), Ctrl+S save, Esc cancel] ", format))
+	de.editor.SetText(doc, false)
+	de.clearError()
+}
+
+func (de *documentEditor) submit() {
+	secret, err := vault.ParseSecretDocument(de.editor.GetText(), de.format)
+	if err != nil {
+		de.showError(err)
+		return
+	}
+	if len(secret) == 0 {
+		de.showError(errors.New("secret must have at least one key"))
+		return
+	}
+	de.clearError()
+	de.save(secret)
+}
+
+// showError shows parse error with the line where error occurred
+func (de *documentEditor) showError(err error) {
+	de.errors.Clear()
+	de.editor.SetBorderColor(tcell.ColorRed)
+	fmt.Fprintf(de.errors, "%s\n", colorfulPrint(tview.Escape(err.Error()), tcell.ColorRed))
+	var docErr *vault.DocumentError
+	if errors.As(err, &docErr) && docErr.Line > 0 {
+		lines := strings.Split(de.editor.GetText(), "\n")
+		if docErr.Line <= len(lines) {
+			fmt.Fprintf(de.errors, "%4d | %s\n", docErr.Line, tview.Escape(lines[docErr.Line-1]))
+			if docErr.Column > 0 {
+				fmt.Fprintf(de.errors, "%s^", strings.Repeat(" ", docErr.Column+6))
+			}
+		}
+	}
+	de.ResizeItem(de.errors, 5, 0)
+}
+
+func (de *documentEditor) clearError() {
+	de.errors.Clear()
+	de.editor.SetBorderColor(tcell.ColorLime)
+	de.ResizeItem(de.errors, 0, 0)
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"vaultview/pkg/constants"
 	"vaultview/pkg/models"
 
@@ -13,6 +14,11 @@ type Info struct {
 	tui *Tui
 }
 
+var (
+	infoLabels   = []string{"VaultView Rev:", "Vault Rev:", "Vault Addr:", "Namespace:", "Token Policies:", "Token Source:", "Token Expires:"}
+	healthLabels = []string{"Sealed:", "Initialized:", "Cluster:", "HA Mode:", "Replication:"}
+)
+
 func NewInfo(tui *Tui) *Info {
 	info := &Info{
 		Table: tview.NewTable(),
@@ -22,11 +28,16 @@ func NewInfo(tui *Tui) *Info {
 	return info
 }
 
+// layout has vault and token info in the first two columns, cluster health in the next two
 func (it *Info) layout() {
-	for row, info := range []string{"VaultView Rev:", "Vault Rev:", "Vault Addr:", "Sealed:", "Token Policies:", "Token Expires:"} {
+	for row, info := range infoLabels {
 		it.Table.SetCell(row, 0, it.getInfoCell(info))
 		it.Table.SetCell(row, 1, it.getInfoValueCell(constants.NAValue))
 	}
+	for row, info := range healthLabels {
+		it.Table.SetCell(row, 2, it.getInfoCell(" "+info))
+		it.Table.SetCell(row, 3, it.getInfoValueCell(constants.NAValue))
+	}
 }
 
 func (it *Info) getInfoCell(info string) *tview.TableCell {
@@ -43,8 +54,12 @@ func (it *Info) getInfoValueCell(info string) *tview.TableCell {
 }
 
 func (it *Info) setCell(row int, newValue string) int {
+	return it.setColumnCell(row, 1, newValue)
+}
+
+func (it *Info) setColumnCell(row, column int, newValue string) int {
 	if newValue != "" {
-		it.GetCell(row, 1).SetText(newValue)
+		it.GetCell(row, column).SetText(newValue)
 	}
 	return row + 1
 }
@@ -54,8 +69,42 @@ func (it *Info) UpdateInfoTable(data models.Info) {
 		nextRow := it.setCell(0, data.VaultViewRev)
 		nextRow = it.setCell(nextRow, data.VaultRev)
 		nextRow = it.setCell(nextRow, data.VaultAddr)
-		nextRow = it.setCell(nextRow, data.Sealed)
+		nextRow = it.setCell(nextRow, data.Namespace)
 		nextRow = it.setCell(nextRow, data.TokenPolicies)
-		nextRow = it.setCell(nextRow, formatDate(data.TokenExpirationTime))
+		nextRow = it.setCell(nextRow, data.TokenSource)
+		nextRow = it.setCell(nextRow, formatExpiration(data.TokenExpirationTime, data.TokenTTL))
+
+		// health values are replaced also when they are gone (e.g. unreachable vault)
+		for row, value := range []string{data.Sealed, data.Initialized, data.ClusterName, data.HAMode, data.Replication} {
+			if value == "" {
+				value = constants.NAValue
+			}
+			it.setColumnCell(row, 3, value)
+		}
+
+		it.setHealthColor(data.Healthy)
 	})
 }
+
+// setHealthColor turns values red when vault is sealed or unreachable
+func (it *Info) setHealthColor(healthy bool) {
+	color := tview.Styles.PrimaryTextColor
+	if !healthy {
+		color = tcell.ColorRed
+	}
+	for row := 0; row < it.GetRowCount(); row++ {
+		for _, column := range []int{1, 3} {
+			if cell := it.GetCell(row, column); cell != nil {
+				cell.SetTextColor(color)
+			}
+		}
+	}
+}
+
+// formatExpiration adds countdown to the expiration date
+func formatExpiration(expireTime, ttl string) string {
+	if ttl == "" {
+		return formatDate(expireTime)
+	}
+	return fmt.Sprintf("%s (%s)", formatDate(expireTime), ttl)
+}
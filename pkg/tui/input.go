@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// InputDialog is centered form with single input field
+type InputDialog struct {
+	*tview.Flex
+	form  *tview.Form
+	value string
+}
+
+func NewInputDialog(title, label, value string, done func(text string), cancel func()) *InputDialog {
+	d := &InputDialog{
+		Flex:  tview.NewFlex(),
+		form:  tview.NewForm(),
+		value: value,
+	}
+
+	d.form.SetBorder(true)
+	d.form.SetTitle(fmt.Sprintf(" [%s::]%s ", tcell.ColorWhite, title))
+	d.form.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetFieldBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+	d.form.SetCancelFunc(cancel)
+	d.form.AddInputField(colorfulPrint(label, tcell.ColorLime), value, 0, nil, func(text string) {
+		d.value = text
+	})
+	d.form.AddButton("OK", func() {
+		done(d.value)
+	})
+	d.form.AddButton("Cancel", cancel)
+
+	d.SetDirection(tview.FlexRow)
+	d.AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(d.form, 60, 1, true).
+			AddItem(nil, 0, 1, false), 7, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	return d
+}
+
+// SetError shows validation error in the title of the dialog
+func (d *InputDialog) SetError(err error) {
+	d.form.SetTitle(fmt.Sprintf(" [%s::]%s ", tcell.ColorRed, err.Error()))
+}
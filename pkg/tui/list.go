@@ -2,19 +2,40 @@ package tui
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+const highlightColor = tcell.ColorYellow
+
+type listItem struct {
+	main, secondary string
+	selected        func()
+}
+
 type List struct {
 	list        *tview.List
 	showSecText bool
+	title       string
+	// all items, list shows only items matching the filter
+	items   []listItem
+	visible []int
+	filter  string
+	regex   bool
+	re      *regexp.Regexp
+	changed func(mainText string)
 }
 
 func NewList(title string, tui *Tui) *List {
 	li := &List{
 		list:        list(title),
 		showSecText: false,
+		title:       fmt.Sprintf(" %v ", title),
 	}
 
 	return li
@@ -28,7 +49,12 @@ func list(title string) *tview.List {
 }
 
 func (l *List) Clear() {
+	l.items = nil
+	l.visible = nil
+	l.filter = ""
 	l.list.Clear()
+	l.updateTitle()
+	l.notifyChanged()
 }
 
 func (l *List) EnableSecText() {
@@ -37,33 +63,83 @@ func (l *List) EnableSecText() {
 }
 
 func (l *List) SetTitle(title string) {
-	l.list.SetTitle(fmt.Sprintf(" %v ", title))
+	l.SetRawTitle(fmt.Sprintf(" %v ", title))
+}
+
+// SetRawTitle sets title as it is (title is not padded), active filter is appended to the title
+func (l *List) SetRawTitle(title string) {
+	l.title = title
+	l.updateTitle()
 }
 
 func (l *List) Hydrate(items []string, selected ...string) {
-	l.list.Clear().SetOffset(0, 0)
-	selectedItem := -1
-	for i, name := range items {
-		if len(selected) > 0 {
-			if selected[0] == name {
-				selectedItem = i
-			}
-		}
-		l.list.AddItem(name, "", 0, nil)
+	l.Clear()
+	l.list.SetOffset(0, 0)
+	for _, name := range items {
+		l.Add(name, "", nil)
 	}
-	if selectedItem >= 0 {
-		l.list.SetCurrentItem(selectedItem)
+	if len(selected) > 0 {
+		l.Select(selected[0])
 	}
 }
 
 func (l *List) Add(item, secItem string, f func()) {
-	l.list.AddItem(item, secItem, 0, f)
+	l.items = append(l.items, listItem{main: item, secondary: secItem, selected: f})
+	if _, ok := l.match(item); ok {
+		l.visible = append(l.visible, len(l.items)-1)
+		l.list.AddItem(l.highlight(item), secItem, 0, f)
+	}
+}
+
+// Count returns number of items (including items hidden by the filter)
+func (l *List) Count() int {
+	return len(l.items)
 }
 
 func (l *List) List() *tview.List {
 	return l.list
 }
 
+// SetChangedFunc sets handler called with the (not highlighted) text of the current item,
+// handler is called with "" when there is no item (list is cleared or filter hides all items)
+func (l *List) SetChangedFunc(handler func(mainText string)) {
+	l.changed = handler
+	l.list.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		handler(l.itemText(index))
+	})
+}
+
+// notifyChanged calls changed handler after the list is rebuilt, tview does not call it when items are replaced
+func (l *List) notifyChanged() {
+	if l.changed != nil {
+		l.changed(l.getItemText())
+	}
+}
+
+// SetSecondaryText changes secondary text of the item
+func (l *List) SetSecondaryText(item, secondary string) {
+	for i := range l.items {
+		if l.items[i].main == item {
+			l.items[i].secondary = secondary
+		}
+	}
+	for i, idx := range l.visible {
+		if l.items[idx].main == item {
+			l.list.SetItemText(i, l.highlight(item), secondary)
+		}
+	}
+}
+
+// Select makes item current, nothing is selected when item is filtered out
+func (l *List) Select(item string) {
+	for i, idx := range l.visible {
+		if l.items[idx].main == item {
+			l.list.SetCurrentItem(i)
+			return
+		}
+	}
+}
+
 func (l *List) NextItem() {
 	nextItem := l.list.GetCurrentItem() + 1
 	if nextItem >= l.list.GetItemCount() {
@@ -74,7 +150,113 @@ func (l *List) NextItem() {
 }
 
 func (l *List) getItemText() string {
-	i := l.list.GetCurrentItem()
-	main, _ := l.list.GetItemText(i)
-	return main
+	return l.itemText(l.list.GetCurrentItem())
+}
+
+func (l *List) itemText(i int) string {
+	if i < 0 || i >= len(l.visible) {
+		return ""
+	}
+	return l.items[l.visible[i]].main
+}
+
+// Filter returns active filter pattern and whether it is regular expression
+func (l *List) Filter() (string, bool) {
+	return l.filter, l.regex
+}
+
+// SetFilter shows only items matching the pattern (fuzzy or regular expression), current item is kept selected
+func (l *List) SetFilter(pattern string, regex bool) error {
+	var re *regexp.Regexp
+	if regex {
+		var err error
+		if re, err = regexp.Compile("(?i)" + pattern); err != nil {
+			return err
+		}
+	}
+	current := l.getItemText()
+	l.filter = pattern
+	l.regex = regex
+	l.re = re
+	l.list.Clear()
+	l.visible = nil
+	for i, item := range l.items {
+		if _, ok := l.match(item.main); ok {
+			l.visible = append(l.visible, i)
+			l.list.AddItem(l.highlight(item.main), item.secondary, 0, item.selected)
+		}
+	}
+	l.Select(current)
+	l.updateTitle()
+	l.notifyChanged()
+	return nil
+}
+
+func (l *List) updateTitle() {
+	if l.filter == "" {
+		l.list.SetTitle(l.title)
+		return
+	}
+	mode := "fuzzy"
+	if l.regex {
+		mode = "regex"
+	}
+	title := strings.TrimSuffix(l.title, " ")
+	l.list.SetTitle(fmt.Sprintf("%s [%s::b]%s %s[-::-] (%d/%d) ", title, highlightColor, mode, tview.Escape(l.filter), len(l.visible), len(l.items)))
+}
+
+// match returns positions (rune indexes) of matched characters
+func (l *List) match(item string) ([]int, bool) {
+	if l.filter == "" {
+		return nil, true
+	}
+	if l.regex {
+		return matchRegex(l.re, item)
+	}
+	return matchFuzzy(l.filter, item)
+}
+
+// matchFuzzy matches characters of the pattern in order (case insensitive)
+func matchFuzzy(pattern, item string) ([]int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	var positions []int
+	for i, r := range []rune(item) {
+		if len(positions) < len(p) && unicode.ToLower(r) == p[len(positions)] {
+			positions = append(positions, i)
+		}
+	}
+	return positions, len(positions) == len(p)
+}
+
+func matchRegex(re *regexp.Regexp, item string) ([]int, bool) {
+	loc := re.FindStringIndex(item)
+	if loc == nil {
+		return nil, false
+	}
+	var positions []int
+	for i := utf8.RuneCountInString(item[:loc[0]]); i < utf8.RuneCountInString(item[:loc[1]]); i++ {
+		positions = append(positions, i)
+	}
+	return positions, true
+}
+
+// highlight colors matched characters of the item
+func (l *List) highlight(item string) string {
+	if l.filter == "" {
+		return item
+	}
+	positions, _ := l.match(item)
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+	var sb strings.Builder
+	for i, r := range []rune(item) {
+		if matched[i] {
+			sb.WriteString(fmt.Sprintf("[%s::b]%s[-::-]", highlightColor, tview.Escape(string(r))))
+		} else {
+			sb.WriteString(tview.Escape(string(r)))
+		}
+	}
+	return sb.String()
 }
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"sort"
+	"vaultview/pkg/vault"
+)
+
+type mergeSide int
+
+const (
+	sideMine mergeSide = iota + 1
+	sideBase
+	sideRemote
+)
+
+// mergeKey is three-way state of single key, nil value means key is not present
+type mergeKey struct {
+	key                string
+	base, mine, remote *vault.SecretValue
+	choice             mergeSide
+	conflict, resolved bool
+}
+
+func sameValue(a, b *vault.SecretValue) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func valueOf(m map[string]vault.SecretValue, k string) *vault.SecretValue {
+	if v, ok := m[k]; ok {
+		return &v
+	}
+	return nil
+}
+
+// threeWayMerge merges my and remote changes made on top of the base,
+// keys changed on both sides in different way are marked as conflicts (my value is preselected)
+func threeWayMerge(base, mine, remote map[string]vault.SecretValue) []*mergeKey {
+	keys := make(map[string]struct{})
+	for _, m := range []map[string]vault.SecretValue{base, mine, remote} {
+		for k := range m {
+			keys[k] = struct{}{}
+		}
+	}
+	merged := []*mergeKey{}
+	for k := range keys {
+		mk := &mergeKey{
+			key:    k,
+			base:   valueOf(base, k),
+			mine:   valueOf(mine, k),
+			remote: valueOf(remote, k),
+		}
+		switch {
+		case sameValue(mk.mine, mk.base):
+			mk.choice = sideRemote
+		case sameValue(mk.remote, mk.base), sameValue(mk.mine, mk.remote):
+			mk.choice = sideMine
+		default:
+			mk.choice = sideMine
+			mk.conflict = true
+		}
+		merged = append(merged, mk)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].key < merged[j].key
+	})
+	return merged
+}
+
+func (mk *mergeKey) value() *vault.SecretValue {
+	switch mk.choice {
+	case sideBase:
+		return mk.base
+	case sideRemote:
+		return mk.remote
+	}
+	return mk.mine
+}
+
+func (mk *mergeKey) choose(side mergeSide) {
+	mk.choice = side
+	mk.resolved = true
+}
+
+// restoreChoices keeps choices made in the previous merge for keys whose remote value did not change,
+// number of keys whose choice was dropped is returned
+func restoreChoices(keys, previous []*mergeKey) int {
+	prev := make(map[string]*mergeKey, len(previous))
+	for _, mk := range previous {
+		prev[mk.key] = mk
+	}
+	dropped := 0
+	for _, mk := range keys {
+		p, ok := prev[mk.key]
+		if !ok || !p.resolved {
+			continue
+		}
+		if !sameValue(p.remote, mk.remote) {
+			dropped++
+			continue
+		}
+		mk.choice = p.choice
+		mk.resolved = true
+	}
+	return dropped
+}
+
+func mergedSecret(keys []*mergeKey) map[string]vault.SecretValue {
+	secret := make(map[string]vault.SecretValue)
+	for _, mk := range keys {
+		if v := mk.value(); v != nil {
+			secret[mk.key] = *v
+		}
+	}
+	return secret
+}
+
+func unresolvedConflicts(keys []*mergeKey) int {
+	n := 0
+	for _, mk := range keys {
+		if mk.conflict && !mk.resolved {
+			n++
+		}
+	}
+	return n
+}
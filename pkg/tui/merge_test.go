@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"testing"
+	"vaultview/pkg/vault"
+)
+
+func str(text string) vault.SecretValue {
+	return vault.SecretValue{Type: vault.TypeString, Text: text}
+}
+
+func TestRestoreChoices(t *testing.T) {
+	base := map[string]vault.SecretValue{"a": str("1"), "b": str("1"), "c": str("1")}
+	mine := map[string]vault.SecretValue{"a": str("2"), "b": str("2"), "c": str("2")}
+	remote := map[string]vault.SecretValue{"a": str("3"), "b": str("3"), "c": str("3")}
+	previous := threeWayMerge(base, mine, remote)
+	for _, mk := range previous {
+		if mk.key != "c" {
+			mk.choose(sideRemote)
+		}
+	}
+
+	// b is changed again remotely
+	remote = map[string]vault.SecretValue{"a": str("3"), "b": str("4"), "c": str("3")}
+	keys := threeWayMerge(base, mine, remote)
+	if dropped := restoreChoices(keys, previous); dropped != 1 {
+		t.Errorf("expected 1 dropped choice, got %d", dropped)
+	}
+	for _, mk := range keys {
+		switch mk.key {
+		case "a":
+			if mk.choice != sideRemote || !mk.resolved {
+				t.Errorf("choice of unchanged key is not kept: %+v", mk)
+			}
+		case "b", "c":
+			if mk.choice != sideMine || mk.resolved {
+				t.Errorf("key %s changed again or not chosen should be unresolved: %+v", mk.key, mk)
+			}
+		}
+	}
+	if n := unresolvedConflicts(keys); n != 2 {
+		t.Errorf("expected 2 unresolved conflicts, got %d", n)
+	}
+}
@@ -2,7 +2,9 @@ package tui
 
 import (
 	"fmt"
-	"os"
+	"slices"
+	"vaultview/pkg/config"
+	"vaultview/pkg/vault"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -10,13 +12,19 @@ import (
 
 type ModalInput struct {
 	*tview.Form
-	tui          *Tui
-	DialogHeight int
-	frame        *tview.Frame
-	primary      string
-	secondary    string
-	err          string
-	done         func(string, string, bool)
+	tui           *Tui
+	DialogHeight  int
+	frame         *tview.Frame
+	primary       string
+	secondary     string
+	namespace     string
+	method        string
+	mount         string
+	creds         vault.Credentials
+	tokenSource   string
+	resolvedToken string
+	err           string
+	done          func(string, string, bool)
 }
 
 func NewModalInput(tui *Tui) *ModalInput {
@@ -25,11 +33,17 @@ func NewModalInput(tui *Tui) *ModalInput {
 	m := &ModalInput{
 		form,
 		tui,
-		9,
+		11,
 		tview.NewFrame(form),
 		"",
 		"",
 		"",
+		"",
+		"",
+		vault.Credentials{},
+		"",
+		"",
+		"",
 		nil,
 	}
 
@@ -68,41 +82,139 @@ func NewModalInput(tui *Tui) *ModalInput {
 }
 
 func (m *ModalInput) Init() {
-	//todo: refactor this
-	if os.Getenv("VAULT_ADDR") != "" {
-		m.AddInputField(colorfulPrint("Vault Addr: ", tcell.ColorLime), os.Getenv("VAULT_ADDR"), 0, nil, func(text string) {
-			m.primary = text
-		})
-	} else {
-		m.AddInputField(colorfulPrint("Vault Addr: ", tcell.ColorLime), "", 0, nil, func(text string) {
-			m.primary = text
-		})
+	// address is prefilled from the active context or VAULT_ADDR
+	m.Clear(false)
+	m.primary = m.tui.cfg.VaultAddr
+	m.secondary, m.tokenSource, m.err = m.resolveToken()
+	m.resolvedToken = m.secondary
+	m.namespace = m.tui.cfg.Namespace
+	m.method = m.tui.cfg.AuthMethod
+	m.mount = m.tui.cfg.AuthMount
+	m.creds = vault.Credentials{Role: m.tui.cfg.AuthRole}
+	m.SetError(m.err)
+	if m.tui.cfg.Context != "" {
+		m.frame.SetTitle(fmt.Sprintf(" [%s::]%s ", tcell.ColorWhite, fmt.Sprintf("[Vault Configuration: %s]", m.tui.cfg.Context)))
 	}
-	if os.Getenv("VAULT_TOKEN") != "" {
-		m.AddPasswordField(colorfulPrint("Vault Token: ", tcell.ColorLime), os.Getenv("VAULT_TOKEN"), 0, '*', func(text string) {
-			m.secondary = text
-		})
-	} else {
-		m.AddPasswordField(colorfulPrint("Vault Token: ", tcell.ColorLime), "", 0, '*', func(text string) {
-			m.secondary = text
-		})
+	m.AddInputField(colorfulPrint("Vault Addr: ", tcell.ColorLime), m.primary, 0, nil, func(text string) {
+		m.primary = text
+	})
+	method := slices.Index(vault.AuthMethods, m.method)
+	if method < 0 {
+		method = 0
 	}
+	m.AddDropDown(colorfulPrint("Auth Method: ", tcell.ColorLime), vault.AuthMethods, -1, nil)
+	// fields below the auth method are rebuilt whenever the method is changed
+	m.GetFormItem(1).(*tview.DropDown).SetSelectedFunc(func(text string, index int) {
+		if index >= 0 && text != m.method {
+			m.method = text
+			m.mount = ""
+		}
+		m.addAuthFields()
+	}).SetCurrentOption(method)
 
 	m.SetDoneFunc(func(primText, secText string, success bool) {
 		if success {
 			m.tui.cfg.UpdateVaultAddr(primText)
-			m.tui.InitVault(primText, secText)
-			err := m.tui.InitMain()
-			if err != nil {
-				m.tui.ShowErrAndStop(err)
+			m.tui.cfg.UpdateNamespace(m.namespace)
+			m.tui.cfg.AuthMethod = m.method
+			m.tui.cfg.AuthMount = m.mount
+			m.tui.cfg.AuthRole = m.creds.Role
+			m.tui.cfg.TokenSource = fmt.Sprintf("%s login", m.method)
+			if m.method == vault.AuthToken {
+				m.tui.cfg.TokenSource = m.tokenSource
+				if secText != m.resolvedToken {
+					m.tui.cfg.TokenSource = config.TokenSourceDialog
+				}
 			}
-		} else {
-			err := m.tui.InitMain()
-			if err != nil {
-				m.tui.ShowErrAndStop(err)
+			if m.method == vault.AuthOIDC {
+				// login is finished in background when browser calls back
+				if err := m.tui.StartOIDCLogin(m.tui.cfg.VaultAddr, m.mount, m.creds.Role); err != nil {
+					m.SetError(err.Error())
+				}
+				return
 			}
+			if m.method == vault.AuthToken {
+				if err := m.tui.InitVault(m.tui.cfg.VaultAddr, secText); err != nil {
+					m.SetError(err.Error())
+					return
+				}
+			} else if err := m.tui.LoginVault(m.tui.cfg.VaultAddr, m.method, m.mount, m.creds); err != nil {
+				m.SetError(err.Error())
+				return
+			}
+		} else {
+			m.tui.restoreContext()
+		}
+		m.tui.RetryMain()
+	})
+}
+
+func (m *ModalInput) resolveToken() (string, string, string) {
+	token, source, err := config.ResolveToken()
+	if err != nil {
+		return "", "", err.Error()
+	}
+	return token, source, ""
+}
+
+// addAuthFields adds credential fields of the selected auth method, mount path and namespace
+func (m *ModalInput) addAuthFields() {
+	for m.GetFormItemCount() > 2 {
+		m.RemoveFormItem(2)
+	}
+	switch m.method {
+	case vault.AuthUserpass, vault.AuthLDAP:
+		m.AddInputField(colorfulPrint("Username: ", tcell.ColorLime), m.creds.Username, 0, nil, func(text string) {
+			m.creds.Username = text
+		})
+		m.AddPasswordField(colorfulPrint("Password: ", tcell.ColorLime), m.creds.Password, 0, '*', func(text string) {
+			m.creds.Password = text
+		})
+	case vault.AuthAppRole:
+		m.AddInputField(colorfulPrint("Role ID: ", tcell.ColorLime), m.creds.RoleID, 0, nil, func(text string) {
+			m.creds.RoleID = text
+		})
+		m.AddPasswordField(colorfulPrint("Secret ID: ", tcell.ColorLime), m.creds.SecretID, 0, '*', func(text string) {
+			m.creds.SecretID = text
+		})
+	case vault.AuthOIDC:
+		m.AddInputField(colorfulPrint("Role: ", tcell.ColorLime), m.creds.Role, 0, nil, func(text string) {
+			m.creds.Role = text
+		})
+	default:
+		m.AddPasswordField(colorfulPrint("Vault Token: ", tcell.ColorLime), m.secondary, 0, '*', func(text string) {
+			m.secondary = text
+		})
+	}
+	if m.method != vault.AuthToken {
+		if m.mount == "" {
+			m.mount = m.method
 		}
+		m.AddInputField(colorfulPrint("Mount Path: ", tcell.ColorLime), m.mount, 0, nil, func(text string) {
+			m.mount = text
+		})
+	}
+	m.AddInputField(colorfulPrint("Namespace: ", tcell.ColorLime), m.namespace, 0, nil, func(text string) {
+		m.namespace = text
 	})
+	m.resize()
+}
+
+// SetError shows error (e.g. failed login) below the form
+func (m *ModalInput) SetError(err string) {
+	m.err = err
+	m.frame.Clear()
+	if err != "" {
+		m.frame.AddText(err, false, tview.AlignCenter, tcell.ColorRed)
+	}
+	m.resize()
+}
+
+func (m *ModalInput) resize() {
+	m.DialogHeight = 5 + 2*m.GetFormItemCount()
+	if m.err != "" {
+		m.DialogHeight++
+	}
 }
 
 func (m *ModalInput) SetValue(text string, secondary string) {
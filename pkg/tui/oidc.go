@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"vaultview/pkg/vault"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"golang.design/x/clipboard"
+)
+
+// OIDCWait is shown while oidc login waits for the callback from the browser, Esc cancels the login
+type OIDCWait struct {
+	*tview.TextView
+	cancel context.CancelFunc
+}
+
+func NewOIDCWait(cancel context.CancelFunc) *OIDCWait {
+	w := &OIDCWait{
+		TextView: tview.NewTextView(),
+		cancel:   cancel,
+	}
+	w.SetDynamicColors(true).
+		SetWrap(true).
+		SetWordWrap(false).
+		SetBorder(true).
+		SetBorderPadding(1, 1, 2, 2).
+		SetTitle(fmt.Sprintf(" [%s::]%s ", tcell.ColorWhite, "[OIDC Login]"))
+	w.SetText("Requesting OIDC auth url from vault...")
+	w.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			w.cancel()
+			return nil
+		}
+		return event
+	})
+	return w
+}
+
+// ShowURL prints auth url for the user to open (it is also copied to clipboard when possible)
+func (w *OIDCWait) ShowURL(authURL string) {
+	copied := ""
+	if err := clipboard.Init(); err == nil {
+		clipboard.Write(clipboard.FmtText, []byte(authURL))
+		copied = " (copied to clipboard)"
+	}
+	w.SetText(fmt.Sprintf("%s%s:\n\n%s\n\n%s",
+		colorfulPrint("Open following URL in your browser to complete login", tcell.ColorLime),
+		copied,
+		tview.Escape(authURL),
+		colorfulPrint("Waiting for the callback... press Esc to cancel", tcell.ColorGray)))
+}
+
+// StartOIDCLogin shows oidc wait screen and runs the login in background,
+// main view is shown after successful login, configuration dialog otherwise
+func (tui *Tui) StartOIDCLogin(addr, mount, role string) error {
+	svc, err := vault.NewVault(addr, "", tui.cfg.Namespace, tui.cfg.TLS)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	wait := NewOIDCWait(cancel)
+	tui.App.SetRoot(wait, true)
+
+	go func() {
+		defer cancel()
+		err := svc.OIDCLogin(ctx, mount, role, "", func(authURL string) {
+			tui.App.QueueUpdateDraw(func() {
+				wait.ShowURL(authURL)
+			})
+		})
+		cancelled := ctx.Err() != nil
+		tui.App.QueueUpdateDraw(func() {
+			if err != nil {
+				tui.ShowConfigModal()
+				if !cancelled {
+					tui.vaultConfigModal.SetError(err.Error())
+				}
+				return
+			}
+			tui.Connect(svc)
+			tui.RetryMain()
+		})
+	}()
+	return nil
+}
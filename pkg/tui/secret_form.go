@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type kvField struct {
+	key, value string
+}
+
+// SecretForm is used to enter path and key/value pairs of the new secret
+type SecretForm struct {
+	*tview.Flex
+	form     *tview.Form
+	row      *tview.Flex
+	basePath string
+	path     string
+	fields   []*kvField
+	save     func(path string, data map[string]any)
+	cancel   func()
+}
+
+func NewSecretForm(basePath string, save func(path string, data map[string]any), cancel func()) *SecretForm {
+	sf := &SecretForm{
+		Flex:     tview.NewFlex(),
+		form:     tview.NewForm(),
+		row:      tview.NewFlex(),
+		basePath: basePath,
+		save:     save,
+		cancel:   cancel,
+	}
+
+	sf.form.SetBorder(true)
+	sf.form.SetTitle(fmt.Sprintf(" [%s::]%s ", tcell.ColorWhite, "[New Secret]"))
+	sf.form.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetFieldBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+	sf.form.SetCancelFunc(sf.cancel)
+
+	sf.row.AddItem(nil, 0, 1, false).
+		AddItem(sf.form, 80, 1, true).
+		AddItem(nil, 0, 1, false)
+	sf.SetDirection(tview.FlexRow)
+	sf.AddItem(nil, 0, 1, false).
+		AddItem(sf.row, 0, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	sf.form.AddInputField(colorfulPrint(fmt.Sprintf("Path: /%s", basePath), tcell.ColorLime), "", 0, nil, func(text string) {
+		sf.path = text
+	})
+	sf.form.AddButton("Add key", sf.addField)
+	sf.form.AddButton("Save", sf.submit)
+	sf.form.AddButton("Cancel", sf.cancel)
+	sf.addField()
+	sf.form.SetFocus(0)
+
+	return sf
+}
+
+func (sf *SecretForm) addField() {
+	field := &kvField{}
+	sf.fields = append(sf.fields, field)
+	n := len(sf.fields)
+	sf.form.AddInputField(colorfulPrint(fmt.Sprintf("Key %d: ", n), tcell.ColorLime), "", 0, nil, func(text string) {
+		field.key = text
+	})
+	sf.form.AddInputField(colorfulPrint(fmt.Sprintf("Value %d: ", n), tcell.ColorLime), "", 0, nil, func(text string) {
+		field.value = text
+	})
+	sf.form.SetFocus(sf.form.GetFormItemCount() - 2)
+	sf.layout()
+}
+
+// layout resizes the form, height depends on number of the fields
+func (sf *SecretForm) layout() {
+	sf.ResizeItem(sf.row, 2*sf.form.GetFormItemCount()+5, 1)
+}
+
+func (sf *SecretForm) submit() {
+	path, data, err := sf.validate()
+	if err != nil {
+		sf.form.SetTitle(fmt.Sprintf(" [%s::]%s ", tcell.ColorRed, err.Error()))
+		return
+	}
+	sf.save(path, data)
+}
+
+func (sf *SecretForm) validate() (string, map[string]any, error) {
+	path := strings.Trim(strings.TrimSpace(sf.path), "/")
+	if path == "" {
+		return "", nil, fmt.Errorf("path is required")
+	}
+	data := make(map[string]any)
+	for i, f := range sf.fields {
+		key := strings.TrimSpace(f.key)
+		if key == "" {
+			return "", nil, fmt.Errorf("key %d is empty", i+1)
+		}
+		if _, ok := data[key]; ok {
+			return "", nil, fmt.Errorf("key '%s' is duplicated", key)
+		}
+		data[key] = f.value
+	}
+	return sf.basePath + path, data, nil
+}
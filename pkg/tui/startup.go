@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"vaultview/pkg/models"
+	"vaultview/pkg/vault"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	startupRetry     = "Retry"
+	startupConfigure = "Configure"
+	startupQuit      = "Quit"
+
+	retryMinDelay = time.Second
+	retryMaxDelay = time.Minute
+)
+
+// StartupError is shown when main view cannot be initialized, health of unreachable or sealed vault
+// is checked in background with backoff and main view is initialized once vault is healthy again
+type StartupError struct {
+	*tview.Modal
+	tui    *Tui
+	err    error
+	status string
+	stop   chan struct{}
+}
+
+func NewStartupError(tui *Tui) *StartupError {
+	se := &StartupError{
+		Modal: tview.NewModal(),
+		tui:   tui,
+	}
+	se.AddButtons([]string{startupRetry, startupConfigure, startupQuit}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			se.stopRetry()
+			switch buttonLabel {
+			case startupRetry:
+				se.tui.RetryMain()
+			case startupConfigure:
+				se.tui.ShowConfigModal()
+			case startupQuit:
+				se.tui.App.Stop()
+			}
+		})
+	return se
+}
+
+// Show displays the error and starts background health checks
+func (se *StartupError) Show(err error) {
+	se.stopRetry()
+	se.err = err
+	se.status = ""
+	step := models.StepConnect
+	var stepErr *models.StepError
+	if errors.As(err, &stepErr) {
+		step = stepErr.Step
+	}
+	if se.tui.vault != nil {
+		se.stop = make(chan struct{})
+		go se.retry(se.stop, step == models.StepHealth, se.tui.vault, se.tui.cfg.VaultAddr)
+	} else {
+		se.status = "fix the configuration to continue"
+	}
+	se.render()
+}
+
+func (se *StartupError) render() {
+	step := models.StepConnect
+	msg := se.err.Error()
+	var stepErr *models.StepError
+	if errors.As(se.err, &stepErr) {
+		step = stepErr.Step
+		msg = stepErr.Err.Error()
+	}
+	se.SetText(fmt.Sprintf("%s\n\n%s %s\n\n%s\n\n%s", colorfulPrint("Vault is not available", tcell.ColorRed), colorfulPrint("Failed step:", tcell.ColorLime), step, tview.Escape(msg), se.status))
+}
+
+func (se *StartupError) setStatus(stop chan struct{}, status string) {
+	se.tui.QueueUpdateDraw(func() {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		se.status = status
+		se.render()
+	})
+}
+
+// retry checks vault health with exponential backoff, main view is initialized
+// when vault becomes healthy and health check was the failing step, client is passed in
+// as tui.vault may be replaced meanwhile
+func (se *StartupError) retry(stop chan struct{}, reinit bool, vaultCli vault.VaultSvc, addr string) {
+	delay := retryMinDelay
+	for attempt := 1; ; attempt++ {
+		se.setStatus(stop, fmt.Sprintf("next health check in %s (attempt %d)", delay, attempt))
+		select {
+		case <-stop:
+			return
+		case <-time.After(delay):
+		}
+		err := models.CheckHealth(vaultCli, addr)
+		if err == nil {
+			if !reinit {
+				se.setStatus(stop, "vault is healthy, select Retry once the problem is fixed")
+				return
+			}
+			se.tui.QueueUpdateDraw(func() {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				se.stopRetry()
+				se.tui.RetryMain()
+			})
+			return
+		}
+		delay = min(delay*2, retryMaxDelay)
+	}
+}
+
+func (se *StartupError) stopRetry() {
+	if se.stop != nil {
+		close(se.stop)
+		se.stop = nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 	"vaultview/pkg/constants"
+	"vaultview/pkg/vault"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -25,6 +26,11 @@ func formatDate(s string) string {
 	return t.Format(dateFormat)
 }
 
+// getValueHash includes type, so value "1" and number 1 are different
+func getValueHash(v vault.SecretValue) string {
+	return getHash(string(v.Type) + ":" + v.Text)
+}
+
 func getHash(s string) string {
 	hash := md5.Sum([]byte(s))
 	checksum := hex.EncodeToString(hash[:])
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"vaultview/pkg/constants"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// AuthView lists enabled auth methods
+type AuthView struct {
+	*tview.Flex
+	tui  *Tui
+	list *List
+}
+
+func NewAuthView(tui *Tui) *AuthView {
+	av := &AuthView{
+		Flex: tview.NewFlex(),
+		tui:  tui,
+		list: NewList(constants.AuthMethodsTitle, tui),
+	}
+
+	av.list.EnableSecText()
+	av.list.List().SetDoneFunc(func() {
+		av.tui.TogglePage(constants.ViewSecretEngines)
+	})
+	av.AddItem(av.list.List(), 0, 3, true)
+	return av
+}
+
+func (av *AuthView) Hydrate(data ...interface{}) error {
+	mounts, err := av.tui.vault.ReadAuthMethods()
+	if err != nil {
+		return err
+	}
+	av.list.Clear()
+	for _, m := range mounts {
+		av.list.Add(m.Path, colorfulPrint(m.Summary(), tcell.ColorGray), nil)
+	}
+	return nil
+}
+
+func (av *AuthView) ClearState() {
+	av.list.Clear()
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"vaultview/pkg/config"
+	"vaultview/pkg/constants"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type ContextView struct {
+	*tview.Flex
+	tui  *Tui
+	list *List
+}
+
+func NewContextView(tui *Tui) *ContextView {
+	cv := &ContextView{
+		Flex: tview.NewFlex(),
+		tui:  tui,
+		list: NewList(constants.ContextsTitle, tui),
+	}
+
+	cv.list.EnableSecText()
+	cv.list.List().SetDoneFunc(func() {
+		cv.tui.TogglePage(constants.ViewSecretEngines)
+	})
+	cv.AddItem(cv.list.List(), 0, 3, true)
+	return cv
+}
+
+func (cv *ContextView) Hydrate(data ...interface{}) error {
+	path, _ := config.FilePath()
+	cv.list.SetTitle(fmt.Sprintf("[%s[::b] %s[::-]]", "Contexts:", path))
+	cv.PopulateList(cv.tui.file.Contexts)
+	return nil
+}
+
+func (cv *ContextView) PopulateList(contexts []config.Context) {
+	cv.list.Clear()
+	for i, ctx := range contexts {
+		name := ctx.Name
+		if ctx.Name == cv.tui.cfg.Context {
+			name += " (active)"
+		}
+		selected := func() {
+			cv.tui.SwitchContext(ctx)
+		}
+		cv.list.Add(name, colorfulPrint(contextDetails(ctx), tcell.ColorGray), selected)
+		if ctx.Name == cv.tui.cfg.Context {
+			cv.list.List().SetCurrentItem(i)
+		}
+	}
+}
+
+func contextDetails(ctx config.Context) string {
+	details := []string{ctx.Address}
+	if ctx.Namespace != "" {
+		details = append(details, fmt.Sprintf("namespace: %s", ctx.Namespace))
+	}
+	if ctx.AuthMethod != "" {
+		details = append(details, fmt.Sprintf("auth: %s", ctx.AuthMethod))
+	}
+	if ctx.DefaultEngine != "" {
+		details = append(details, fmt.Sprintf("engine: %s", ctx.DefaultEngine))
+	}
+	return strings.Join(details, " | ")
+}
@@ -0,0 +1,236 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"vaultview/pkg/constants"
+	"vaultview/pkg/models"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// grepBatch collects folders listed and hits found by grep workers since the last flush
+type grepBatch struct {
+	mx     sync.Mutex
+	listed map[string][]string
+	hits   []models.GrepHit
+}
+
+func (b *grepBatch) take() (map[string][]string, []models.GrepHit) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	listed, hits := b.listed, b.hits
+	b.listed, b.hits = make(map[string][]string), nil
+	return listed, hits
+}
+
+type GrepViewI interface {
+	View
+	HasResults() bool
+}
+
+// GrepView lists keys of secrets whose name or value matches the pattern, values are masked until revealed
+type GrepView struct {
+	*tview.Flex
+	tui                   *Tui
+	list                  *List
+	grep                  *models.Grep
+	engine, root, pattern string
+	scope                 models.GrepScope
+	hits                  map[string]models.GrepHit
+	revealed              map[string]bool
+	current               string
+}
+
+func NewGrepView(tui *Tui) *GrepView {
+	gv := &GrepView{
+		Flex:     tview.NewFlex(),
+		tui:      tui,
+		list:     NewList(constants.GrepTitle, tui),
+		hits:     make(map[string]models.GrepHit),
+		revealed: make(map[string]bool),
+	}
+
+	gv.list.EnableSecText()
+	gv.list.SetChangedFunc(func(mainText string) {
+		gv.current = mainText
+	})
+	gv.list.List().SetDoneFunc(func() {
+		gv.stop()
+		gv.tui.TogglePage(constants.ViewSecrets)
+	})
+	gv.list.List().SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == constants.Reveal {
+			gv.toggleReveal()
+			return nil
+		} else if event.Rune() == constants.Filter {
+			gv.tui.ShowFilter(gv.list)
+			return nil
+		}
+		return event
+	})
+	gv.AddItem(gv.list.List(), 0, 3, true)
+	return gv
+}
+
+// Hydrate starts grep of the pattern (case insensitive) in secrets below root of the engine,
+// running grep is stopped
+func (gv *GrepView) Hydrate(data ...interface{}) error {
+	if len(data) < 4 {
+		return fmt.Errorf("error during type assertion")
+	}
+	engine, ok1 := data[0].(string)
+	root, ok2 := data[1].(string)
+	pattern, ok3 := data[2].(string)
+	scope, ok4 := data[3].(models.GrepScope)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return fmt.Errorf("error during type assertion")
+	}
+	gv.stop()
+	gv.list.Clear()
+	gv.hits = make(map[string]models.GrepHit)
+	gv.revealed = make(map[string]bool)
+	gv.engine, gv.root, gv.pattern, gv.scope = engine, root, pattern, scope
+
+	lower := strings.ToLower(pattern)
+	batch := &grepBatch{listed: make(map[string][]string)}
+	grep := models.NewGrep(gv.tui.vault, engine, func(s string) bool {
+		return strings.Contains(strings.ToLower(s), lower)
+	}, scope, func(dir string, items []string) {
+		batch.mx.Lock()
+		batch.listed[dir] = items
+		batch.mx.Unlock()
+	}, func(h models.GrepHit) {
+		batch.mx.Lock()
+		batch.hits = append(batch.hits, h)
+		batch.mx.Unlock()
+	})
+	gv.grep = grep
+	gv.setTitle(grep.Stats(), true)
+	go gv.run(grep, batch, root)
+	return nil
+}
+
+// run greps root (not gv.root, view may be hydrated again meanwhile) and flushes results periodically
+func (gv *GrepView) run(grep *models.Grep, batch *grepBatch, root string) {
+	done := make(chan models.GrepStats)
+	go func() {
+		done <- grep.Run(root)
+	}()
+	ticker := time.NewTicker(searchFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case stats := <-done:
+			gv.flush(grep, batch, stats, false)
+			return
+		case <-ticker.C:
+			gv.flush(grep, batch, grep.Stats(), true)
+		}
+	}
+}
+
+// flush adds hits to the list and listed folders to the secrets cache, progress is shown in the header
+func (gv *GrepView) flush(grep *models.Grep, batch *grepBatch, stats models.GrepStats, running bool) {
+	listed, hits := batch.take()
+	gv.tui.App.QueueUpdateDraw(func() {
+		if gv.grep != grep {
+			// grep was restarted or view was cleared
+			return
+		}
+		secretView := gv.tui.views[constants.ViewSecrets].(SecretViewI)
+		for dir, items := range listed {
+			secretView.CacheList(gv.engine, dir, items)
+		}
+		for _, h := range hits {
+			item := fmt.Sprintf("%s : %s", h.Path, h.Key)
+			gv.hits[item] = h
+			gv.list.Add(item, gv.hitText(h, false), func() {
+				gv.open(h)
+			})
+		}
+		gv.setTitle(stats, running)
+		if running {
+			gv.tui.PublishProgress(fmt.Sprintf("grep %s/%s: %d/%d secrets read, %d hits", gv.engine, gv.root, stats.Read, stats.Secrets, stats.Hits))
+			return
+		}
+		gv.tui.PublishProgress("")
+		msg := fmt.Sprintf("grep finished: %d hits in %d secrets", stats.Hits, stats.Read)
+		if stats.Stopped {
+			msg = fmt.Sprintf("grep stopped: %d hits in %d secrets", stats.Hits, stats.Read)
+		}
+		gv.tui.ShowStatusAndContinue(msg, InfoStatus)
+	})
+}
+
+func (gv *GrepView) setTitle(stats models.GrepStats, running bool) {
+	state := "done"
+	if running {
+		state = "reading..."
+	} else if stats.Stopped {
+		state = "stopped"
+	}
+	title := fmt.Sprintf("[%s[::b] %s[::-] in [::b]%s/%s[::-], %s[::b] %d[::-], %s[::b] %d/%d[::-], %s]",
+		"Grep:", tview.Escape(gv.pattern), gv.engine, gv.root, "Hits:", stats.Hits, "Read:", stats.Read, stats.Secrets, state)
+	if stats.Failed > 0 {
+		title = strings.TrimSuffix(title, "]") + fmt.Sprintf(", %s[::b] %d[::-]]", "Failed:", stats.Failed)
+	}
+	gv.list.SetTitle(title)
+}
+
+// hitText is (masked) value of the hit with the matched part of the key
+func (gv *GrepView) hitText(h models.GrepHit, revealed bool) string {
+	value := constants.Mask
+	if revealed {
+		value = tview.Escape(h.Value.Text)
+	}
+	var matched []string
+	if h.InKey {
+		matched = append(matched, "key")
+	}
+	if h.InValue {
+		matched = append(matched, "value")
+	}
+	return fmt.Sprintf("%s %s", value, colorfulPrint(fmt.Sprintf("(%s match)", strings.Join(matched, ", ")), tcell.ColorGray))
+}
+
+func (gv *GrepView) toggleReveal() {
+	h, ok := gv.hits[gv.current]
+	if !ok {
+		return
+	}
+	gv.revealed[gv.current] = !gv.revealed[gv.current]
+	gv.list.SetSecondaryText(gv.current, gv.hitText(h, gv.revealed[gv.current]))
+}
+
+// open shows secret of the hit with the key selected, grep continues in background
+func (gv *GrepView) open(h models.GrepHit) {
+	if err := gv.tui.ChangePath(gv.engine + "/" + h.Path); err != nil {
+		gv.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	gv.tui.views[constants.ViewSecretData].(SecretDataViewI).SelectKey(h.Key)
+}
+
+func (gv *GrepView) stop() {
+	if gv.grep != nil {
+		gv.grep.Stop()
+	}
+}
+
+// HasResults reports whether grep was started, results are kept until the next grep
+func (gv *GrepView) HasResults() bool {
+	return gv.grep != nil
+}
+
+func (gv *GrepView) ClearState() {
+	gv.stop()
+	gv.grep = nil
+	gv.list.Clear()
+	gv.hits = make(map[string]models.GrepHit)
+	gv.revealed = make(map[string]bool)
+	gv.tui.PublishProgress("")
+}
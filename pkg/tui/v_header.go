@@ -1,8 +1,10 @@
 package tui
 
 import (
+	"fmt"
 	"vaultview/pkg/models"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -10,7 +12,9 @@ type HeaderViewI interface {
 	View
 	Info(msg string)
 	Err(msg string)
+	Warn(msg string)
 	Success(msg string)
+	Progress(msg string)
 	Reset()
 }
 
@@ -20,6 +24,8 @@ type HeaderView struct {
 	logo        *Logo
 	infoTable   *Info
 	placeholder *tview.TextView
+	watcher     *models.TokenWatcher
+	health      *models.HealthWatcher
 }
 
 type StatusType int
@@ -28,6 +34,7 @@ const (
 	ErrStatus StatusType = iota + 1
 	InfoStatus
 	SuccessStatus
+	WarnStatus
 )
 
 func NewHeaderView(tui *Tui) *HeaderView {
@@ -38,9 +45,10 @@ func NewHeaderView(tui *Tui) *HeaderView {
 		placeholder: tview.NewTextView(),
 	}
 	header.infoTable = NewInfo(tui)
+	header.placeholder.SetDynamicColors(true).SetTextAlign(tview.AlignCenter)
 
 	header.SetDirection(tview.FlexColumn)
-	header.AddItem(header.infoTable, 80, 1, false).
+	header.AddItem(header.infoTable, 110, 1, false).
 		AddItem(header.placeholder, 0, 1, false).
 		AddItem(header.logo, 46, 1, false)
 
@@ -48,15 +56,47 @@ func NewHeaderView(tui *Tui) *HeaderView {
 }
 
 func (hw *HeaderView) Hydrate(data ...interface{}) error {
+	hw.stopWatchers()
 	infoModel, err := models.NewInfo(hw.tui.vault, hw.tui.cfg)
 	if err != nil {
 		return err
 	}
 	infoModel.RegisterListener(hw.infoTable)
 	infoModel.TriggerInfoChange()
+
+	hw.watcher = models.NewTokenWatcher(hw.tui.vault, infoModel, hw.status(WarnStatus))
+	hw.health = models.NewHealthWatcher(infoModel, hw.status(ErrStatus), hw.status(SuccessStatus))
+	go hw.watcher.Run()
+	go hw.health.Run()
 	return nil
 }
 
+// ClearState stops background watchers of the old connection and resets info table
+func (hw *HeaderView) ClearState() {
+	hw.stopWatchers()
+	hw.infoTable.layout()
+}
+
+func (hw *HeaderView) stopWatchers() {
+	if hw.watcher != nil {
+		hw.watcher.Stop()
+		hw.watcher = nil
+	}
+	if hw.health != nil {
+		hw.health.Stop()
+		hw.health = nil
+	}
+}
+
+// status returns function raising status message from background watchers
+func (hw *HeaderView) status(statusType StatusType) func(msg string) {
+	return func(msg string) {
+		hw.tui.QueueUpdateDraw(func() {
+			hw.tui.ShowStatusAndContinue(msg, statusType)
+		})
+	}
+}
+
 func (hw *HeaderView) Success(msg string) {
 	hw.logo.Success(msg)
 }
@@ -69,6 +109,18 @@ func (hw *HeaderView) Err(msg string) {
 	hw.logo.Err(msg)
 }
 
+func (hw *HeaderView) Warn(msg string) {
+	hw.logo.Warn(msg)
+}
+
+// Progress shows progress of the background job next to the info table, empty message hides it
+func (hw *HeaderView) Progress(msg string) {
+	hw.placeholder.Clear()
+	if msg != "" {
+		fmt.Fprintf(hw.placeholder, "\n\n[%s::b]%s[-::-]", tcell.ColorYellow, msg)
+	}
+}
+
 func (hw *HeaderView) Reset() {
 	hw.logo.Reset()
 }
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"vaultview/pkg/constants"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type NamespaceView struct {
+	*tview.Flex
+	tui  *Tui
+	list *List
+}
+
+func NewNamespaceView(tui *Tui) *NamespaceView {
+	nv := &NamespaceView{
+		Flex: tview.NewFlex(),
+		tui:  tui,
+		list: NewList(constants.NamespacesTitle, tui),
+	}
+
+	nv.list.EnableSecText()
+	nv.list.List().SetDoneFunc(func() {
+		nv.tui.TogglePage(constants.ViewSecretEngines)
+	})
+	nv.AddItem(nv.list.List(), 0, 3, true)
+	return nv
+}
+
+func (nv *NamespaceView) Hydrate(data ...interface{}) error {
+	namespaces, err := nv.tui.vault.ListNamespaces()
+	if err != nil {
+		return err
+	}
+	current := nv.tui.cfg.Namespace
+	if current == "" {
+		current = "root"
+	}
+	nv.list.SetTitle(fmt.Sprintf("[%s[::b] %s[::-]]", "Namespaces:", current))
+	nv.PopulateList(namespaces)
+	return nil
+}
+
+// PopulateList lists child namespaces of the current one, '..' leads to the parent namespace
+func (nv *NamespaceView) PopulateList(namespaces []string) {
+	nv.list.Clear()
+	current := nv.tui.cfg.Namespace
+	if current != "" {
+		parent := ""
+		if i := strings.LastIndex(current, "/"); i >= 0 {
+			parent = current[:i]
+		}
+		nv.list.Add("..", colorfulPrint("parent namespace", tcell.ColorGray), func() {
+			nv.tui.SwitchNamespace(parent)
+		})
+	}
+	for _, ns := range namespaces {
+		child := strings.TrimSuffix(ns, "/")
+		if current != "" {
+			child = current + "/" + child
+		}
+		nv.list.Add(ns, colorfulPrint(child, tcell.ColorGray), func() {
+			nv.tui.SwitchNamespace(child)
+		})
+	}
+}
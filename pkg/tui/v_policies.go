@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"vaultview/pkg/constants"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// PolicyView lists acl policies, Enter shows the policy document
+type PolicyView struct {
+	*tview.Flex
+	tui    *Tui
+	list   *List
+	policy *tview.TextView
+}
+
+func NewPolicyView(tui *Tui) *PolicyView {
+	pv := &PolicyView{
+		Flex:   tview.NewFlex(),
+		tui:    tui,
+		list:   NewList(constants.PoliciesTitle, tui),
+		policy: tview.NewTextView(),
+	}
+
+	pv.policy.SetBorder(true)
+	pv.policy.SetWrap(true)
+	pv.policy.SetDoneFunc(func(key tcell.Key) {
+		pv.closePolicy()
+	})
+	pv.list.List().SetDoneFunc(func() {
+		pv.tui.TogglePage(constants.ViewSecretEngines)
+	})
+	pv.AddItem(pv.list.List(), 0, 3, true)
+	pv.AddItem(pv.policy, 0, 0, false)
+	return pv
+}
+
+func (pv *PolicyView) Hydrate(data ...interface{}) error {
+	policies, err := pv.tui.vault.ListPolicies()
+	if err != nil {
+		return err
+	}
+	pv.closePolicy()
+	pv.list.Clear()
+	for _, name := range policies {
+		pv.list.Add(name, "", func() {
+			pv.showPolicy(name)
+		})
+	}
+	return nil
+}
+
+func (pv *PolicyView) ClearState() {
+	pv.list.Clear()
+	pv.policy.Clear()
+}
+
+func (pv *PolicyView) showPolicy(name string) {
+	policy, err := pv.tui.vault.ReadPolicy(name)
+	if err != nil {
+		pv.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	pv.policy.SetTitle(fmt.Sprintf(" [[::b]%s[::-]] ", tview.Escape(name)))
+	pv.policy.SetText(policy).ScrollToBeginning()
+	pv.ResizeItem(pv.list.List(), 0, 1)
+	pv.ResizeItem(pv.policy, 0, 3)
+	pv.tui.App.SetFocus(pv.policy)
+}
+
+func (pv *PolicyView) closePolicy() {
+	pv.policy.Clear()
+	pv.ResizeItem(pv.policy, 0, 0)
+	pv.ResizeItem(pv.list.List(), 0, 3)
+	pv.tui.App.SetFocus(pv.list.List())
+}
@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"vaultview/pkg/constants"
+	"vaultview/pkg/models"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// results of the crawl are passed to the view in batches
+const searchFlushInterval = 200 * time.Millisecond
+
+// searchBatch collects folders listed and paths found by crawler workers since the last flush
+type searchBatch struct {
+	mx     sync.Mutex
+	listed map[string][]string
+	found  []string
+}
+
+func (b *searchBatch) take() (map[string][]string, []string) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	listed, found := b.listed, b.found
+	b.listed, b.found = make(map[string][]string), nil
+	return listed, found
+}
+
+type SearchViewI interface {
+	View
+	HasResults() bool
+}
+
+// SearchView streams secret paths matching the pattern found by recursive crawl of the engine
+type SearchView struct {
+	*tview.Flex
+	tui                   *Tui
+	list                  *List
+	crawler               *models.Crawler
+	engine, root, pattern string
+}
+
+func NewSearchView(tui *Tui) *SearchView {
+	sv := &SearchView{
+		Flex: tview.NewFlex(),
+		tui:  tui,
+		list: NewList(constants.SearchTitle, tui),
+	}
+
+	sv.list.List().SetDoneFunc(func() {
+		sv.stop()
+		sv.tui.TogglePage(constants.ViewSecrets)
+	})
+	sv.list.List().SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == constants.Filter {
+			sv.tui.ShowFilter(sv.list)
+			return nil
+		}
+		return event
+	})
+	sv.AddItem(sv.list.List(), 0, 3, true)
+	return sv
+}
+
+// Hydrate starts search of the pattern (case insensitive) in paths below root of the engine,
+// running search is stopped
+func (sv *SearchView) Hydrate(data ...interface{}) error {
+	if len(data) < 3 {
+		return fmt.Errorf("error during type assertion")
+	}
+	engine, ok1 := data[0].(string)
+	root, ok2 := data[1].(string)
+	pattern, ok3 := data[2].(string)
+	if !ok1 || !ok2 || !ok3 {
+		return fmt.Errorf("error during type assertion")
+	}
+	sv.stop()
+	sv.list.Clear()
+	sv.engine, sv.root, sv.pattern = engine, root, pattern
+
+	lower := strings.ToLower(pattern)
+	batch := &searchBatch{listed: make(map[string][]string)}
+	crawler := models.NewCrawler(sv.tui.vault, engine, func(p string) bool {
+		return strings.Contains(strings.ToLower(p), lower)
+	}, func(dir string, items []string) {
+		batch.mx.Lock()
+		batch.listed[dir] = items
+		batch.mx.Unlock()
+	}, func(p string) {
+		batch.mx.Lock()
+		batch.found = append(batch.found, p)
+		batch.mx.Unlock()
+	})
+	sv.crawler = crawler
+	sv.setTitle(crawler.Stats(), true)
+	go sv.run(crawler, batch, root)
+	return nil
+}
+
+// run crawls root (not sv.root, view may be hydrated again meanwhile) and flushes results periodically
+func (sv *SearchView) run(crawler *models.Crawler, batch *searchBatch, root string) {
+	done := make(chan models.CrawlStats)
+	go func() {
+		done <- crawler.Run(root)
+	}()
+	ticker := time.NewTicker(searchFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case stats := <-done:
+			sv.flush(crawler, batch, stats, false)
+			return
+		case <-ticker.C:
+			sv.flush(crawler, batch, crawler.Stats(), true)
+		}
+	}
+}
+
+// flush adds found paths to the list and listed folders to the secrets cache, so opened results are not listed again
+func (sv *SearchView) flush(crawler *models.Crawler, batch *searchBatch, stats models.CrawlStats, running bool) {
+	listed, found := batch.take()
+	sv.tui.App.QueueUpdateDraw(func() {
+		if sv.crawler != crawler {
+			// search was restarted or view was cleared
+			return
+		}
+		secretView := sv.tui.views[constants.ViewSecrets].(SecretViewI)
+		for dir, items := range listed {
+			secretView.CacheList(sv.engine, dir, items)
+		}
+		for _, p := range found {
+			sv.list.Add(p, "", func() {
+				sv.open(p)
+			})
+		}
+		sv.setTitle(stats, running)
+	})
+}
+
+func (sv *SearchView) setTitle(stats models.CrawlStats, running bool) {
+	state := "done"
+	if running {
+		state = "searching..."
+	} else if stats.Stopped {
+		state = "stopped"
+	}
+	title := fmt.Sprintf("[%s[::b] %s[::-] in [::b]%s/%s[::-], %s[::b] %d[::-], %s[::b] %d[::-], %s]",
+		"Search:", tview.Escape(sv.pattern), sv.engine, sv.root, "Found:", sv.list.Count(), "Folders:", stats.Folders, state)
+	if stats.Failed > 0 {
+		title = strings.TrimSuffix(title, "]") + fmt.Sprintf(", %s[::b] %d[::-]]", "Failed:", stats.Failed)
+	}
+	sv.list.SetTitle(title)
+}
+
+// open shows secret (or folder) found by the search, search continues in background
+func (sv *SearchView) open(p string) {
+	if err := sv.tui.ChangePath(sv.engine + "/" + p); err != nil {
+		sv.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+	}
+}
+
+func (sv *SearchView) stop() {
+	if sv.crawler != nil {
+		sv.crawler.Stop()
+	}
+}
+
+// HasResults reports whether search was started, results are kept until the next search
+func (sv *SearchView) HasResults() bool {
+	return sv.crawler != nil
+}
+
+func (sv *SearchView) ClearState() {
+	sv.stop()
+	sv.crawler = nil
+	sv.list.Clear()
+}
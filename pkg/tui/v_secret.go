@@ -3,9 +3,12 @@ package tui
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"sort"
 	"strings"
 	"vaultview/pkg/constants"
 	"vaultview/pkg/utils"
+	"vaultview/pkg/vault"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -14,6 +17,10 @@ import (
 type SecretViewI interface {
 	View
 	SecretsHardRefresh()
+	HydratePath(engine, p string) ([]string, error)
+	CachedList(engine, p string) ([]string, error)
+	CacheList(engine, p string, secrets []string)
+	Location() (engine, p string)
 }
 
 type SecretView struct {
@@ -37,12 +44,13 @@ func NewSecretView(tui *Tui) *SecretView {
 	sw.SetDirection(tview.FlexRow)
 	sw.AddItem(sw.path, 3, 1, false)
 
-	sw.list.List().SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+	sw.list.SetChangedFunc(func(mainText string) {
 		sw.currentSecret = mainText
 	})
 	sw.list.List().SetDoneFunc(func() {
 		p := sw.getPath()
-		sw.list.List().Clear().SetOffset(0, 0)
+		sw.list.Clear()
+		sw.list.List().SetOffset(0, 0)
 		if p == "" {
 			sw.path.Clear()
 			sw.setEngine("")
@@ -62,11 +70,12 @@ func (sw *SecretView) hydratePreviousSecret(p string) {
 	parentPath := utils.GetParentPath(p)
 	selectChild := utils.GetChildPath(p)
 	sw.setPath(parentPath)
-	if parentPath != "" {
-		sw.list.Hydrate(sw.cachedSecrets[sw.getCachedSecretKey(parentPath)], selectChild)
-	} else {
-		sw.list.Hydrate(sw.cachedSecrets[""])
+	// parent is not cached when path was opened directly (e.g. by :cd command)
+	secrets, err := sw.CachedList(sw.engine, parentPath)
+	if err != nil {
+		sw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
 	}
+	sw.list.Hydrate(secrets, selectChild)
 }
 
 func (sw *SecretView) defineEvents() {
@@ -77,6 +86,29 @@ func (sw *SecretView) defineEvents() {
 		} else if event.Key() == tcell.KeyCtrlR {
 			sw.secretsHardRefresh()
 			return nil
+		} else if event.Rune() == constants.New {
+			sw.newSecret()
+			return nil
+		} else if event.Rune() == constants.Delete {
+			sw.deleteSecret()
+			return nil
+		} else if event.Rune() == constants.Purge {
+			sw.purgeSecret()
+			return nil
+		} else if event.Rune() == constants.Filter {
+			sw.tui.ShowFilter(sw.list)
+			return nil
+		} else if event.Rune() == constants.Search {
+			sw.tui.commandBar.Prompt("search ")
+			return nil
+		} else if event.Rune() == constants.Grep {
+			sw.tui.commandBar.Prompt("grep ")
+			return nil
+		} else if event.Rune() == constants.Tree {
+			if err := sw.tui.ShowTreeView(""); err != nil {
+				sw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			}
+			return nil
 		}
 		return event
 	})
@@ -122,12 +154,49 @@ func (sw *SecretView) Hydrate(data ...interface{}) error {
 	if err != nil {
 		return err
 	}
-	sw.cachedSecrets[""] = vs
-	sw.list.Hydrate(sw.cachedSecrets[""])
+	sw.cachedSecrets[sw.getCachedSecretKey("")] = vs
+	sw.list.Hydrate(vs)
 
 	return nil
 }
 
+// HydratePath opens path (folder) of the engine, listed secrets are returned
+func (sw *SecretView) HydratePath(engine, p string) ([]string, error) {
+	secrets, err := sw.CachedList(engine, p)
+	if err != nil {
+		return nil, err
+	}
+	sw.setEngine(engine)
+	sw.setPathTitle(engine)
+	sw.setPath(p)
+	sw.list.Hydrate(secrets)
+	return secrets, nil
+}
+
+// CachedList lists secrets of the engine path, vault is called only if the path is not cached yet
+func (sw *SecretView) CachedList(engine, p string) ([]string, error) {
+	key := engine + ":" + p
+	if secrets, ok := sw.cachedSecrets[key]; ok {
+		return secrets, nil
+	}
+	secrets, err := sw.tui.vault.ListKvSecrets(engine, p)
+	if err != nil {
+		return nil, err
+	}
+	sw.cachedSecrets[key] = secrets
+	return secrets, nil
+}
+
+// CacheList caches secrets of the engine path listed elsewhere (e.g. by search)
+func (sw *SecretView) CacheList(engine, p string, secrets []string) {
+	sw.cachedSecrets[engine+":"+p] = secrets
+}
+
+// Location returns engine and path shown in the view
+func (sw *SecretView) Location() (string, string) {
+	return sw.engine, sw.getPath()
+}
+
 func (sw *SecretView) SelectedSecret() {
 	p := sw.getPath() + sw.currentSecret
 	sePath := sw.getCachedSecretKey(p)
@@ -177,3 +246,151 @@ func (sw *SecretView) secretsHardRefresh() {
 func (sw *SecretView) SecretsHardRefresh() {
 	sw.secretsHardRefresh()
 }
+
+// ClearState drops cached secrets and current position
+func (sw *SecretView) ClearState() {
+	sw.cachedSecrets = make(map[string][]string)
+	sw.list.Clear()
+	sw.path.Clear()
+	sw.setEngine("")
+	sw.currentSecret = ""
+}
+
+// newSecret opens form for the new secret, path is relative to the current path
+func (sw *SecretView) newSecret() {
+	form := NewSecretForm(sw.getPath(), func(p string, data map[string]any) {
+		sw.tui.HideModal()
+		exists, err := sw.secretExists(p)
+		if err != nil {
+			sw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		if exists {
+			sw.tui.ShowConfirm(fmt.Sprintf("Secret '%s' already exists.\nOverwrite it?", p), func() {
+				sw.writeNewSecret(p, data)
+			})
+			return
+		}
+		sw.writeNewSecret(p, data)
+	}, sw.tui.HideModal)
+	sw.tui.ShowModal(form)
+}
+
+// secretExists checks metadata of kv v2 secret, so secret with soft deleted latest version exists too
+func (sw *SecretView) secretExists(p string) (bool, error) {
+	kvVersion, err := sw.tui.vault.KvVersion(sw.engine)
+	if err != nil {
+		return false, err
+	}
+	if kvVersion == vault.KvV1 {
+		_, _, err = sw.tui.vault.ReadKvSecret(sw.engine, p)
+	} else {
+		_, err = sw.tui.vault.ReadKvSecretMetadata(sw.engine, p)
+	}
+	if err == nil {
+		return true, nil
+	}
+	if sw.tui.vault.IsErrorStatus(err, http.StatusNotFound) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (sw *SecretView) writeNewSecret(p string, data map[string]any) {
+	if err := sw.tui.vault.WriteKvSecret(sw.engine, p, data); err != nil {
+		sw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	sw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret '%s' created successfuly", p), SuccessStatus)
+	sw.cacheNewSecret(p)
+}
+
+// cacheNewSecret adds new secret (and its parent folders) to the cached lists,
+// so secret is visible without hard refresh
+func (sw *SecretView) cacheNewSecret(p string) {
+	parent := ""
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		item := part
+		if i < len(parts)-1 {
+			item += "/"
+		}
+		key := sw.cacheKey(parent)
+		if items, ok := sw.cachedSecrets[key]; ok && !slices.Contains(items, item) {
+			items = append(items, item)
+			sort.Strings(items)
+			sw.cachedSecrets[key] = items
+		}
+		if parent == sw.getPath() {
+			sw.list.Hydrate(sw.cachedSecrets[key], item)
+		}
+		parent += item
+	}
+}
+
+// cacheKey returns key of cached secrets for the path
+func (sw *SecretView) cacheKey(p string) string {
+	return sw.getCachedSecretKey(p)
+}
+
+// deleteSecret soft deletes the latest version of the selected secret (kv v1 secret is deleted permanently)
+func (sw *SecretView) deleteSecret() {
+	name := sw.currentSecret
+	if name == "" || strings.HasSuffix(name, "/") {
+		return
+	}
+	p := sw.getPath() + name
+	kvVersion, err := sw.tui.vault.KvVersion(sw.engine)
+	if err != nil {
+		sw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	msg := fmt.Sprintf("Soft delete the latest version of secret\n[::b]%s/%s[::-]\nVersion can be restored with undelete.", sw.engine, p)
+	if kvVersion == vault.KvV1 {
+		msg = fmt.Sprintf("Permanently delete kv v1 secret\n[::b]%s/%s[::-]\nThis cannot be undone.", sw.engine, p)
+	}
+	sw.tui.ShowTypedConfirm("[Delete Secret]", msg, name, func() {
+		if err := sw.tui.vault.DeleteKvSecret(sw.engine, p); err != nil {
+			sw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		sw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret '%s' deleted", name), SuccessStatus)
+		if kvVersion == vault.KvV1 {
+			sw.uncacheSecret(name)
+		}
+	})
+}
+
+// purgeSecret deletes metadata and all versions of the selected secret
+func (sw *SecretView) purgeSecret() {
+	name := sw.currentSecret
+	if name == "" || strings.HasSuffix(name, "/") {
+		return
+	}
+	p := sw.getPath() + name
+	kvVersion, err := sw.tui.vault.KvVersion(sw.engine)
+	if err != nil {
+		sw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	if kvVersion == vault.KvV1 {
+		sw.deleteSecret()
+		return
+	}
+	msg := fmt.Sprintf("Permanently delete metadata and [::b]all versions[::-] of secret\n[::b]%s/%s[::-]\nThis cannot be undone.", sw.engine, p)
+	sw.tui.ShowTypedConfirm("[Delete All Versions]", msg, name, func() {
+		if err := sw.tui.vault.DeleteKvSecretMetadata(sw.engine, p); err != nil {
+			sw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		sw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret '%s' and all its versions deleted", name), SuccessStatus)
+		sw.uncacheSecret(name)
+	})
+}
+
+// uncacheSecret removes secret from the current path list
+func (sw *SecretView) uncacheSecret(name string) {
+	key := sw.cacheKey(sw.getPath())
+	sw.cachedSecrets[key] = utils.RemoveFromSlice(sw.cachedSecrets[key], name)
+	sw.list.Hydrate(sw.cachedSecrets[key])
+}
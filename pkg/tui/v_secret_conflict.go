@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"vaultview/pkg/constants"
+	"vaultview/pkg/utils"
+	"vaultview/pkg/vault"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SecretConflictView is shown when save fails on check-and-set,
+// it compares my edits, version edits started from and current remote version
+type SecretConflictView struct {
+	*tview.Flex
+	tui                   *Tui
+	list                  *List
+	values                *tview.TextView
+	secretEng, secretPath string
+	secretName            string
+	baseVersion           int
+	remoteVersion         int
+	base, mine            map[string]vault.SecretValue
+	keys                  []*mergeKey
+	reveal                bool
+}
+
+func (scw *SecretConflictView) ClearState() {
+	scw.list.Clear()
+	scw.values.Clear()
+	scw.secretEng, scw.secretPath, scw.secretName = "", "", ""
+	scw.base, scw.mine, scw.keys = nil, nil, nil
+	scw.reveal = false
+}
+
+func NewSecretConflictView(tui *Tui) *SecretConflictView {
+	scw := &SecretConflictView{
+		Flex:   tview.NewFlex(),
+		tui:    tui,
+		list:   NewList(constants.ConflictTitle, tui),
+		values: tview.NewTextView(),
+	}
+
+	scw.values.SetBorder(true)
+	scw.values.SetWrap(true)
+	scw.values.SetDynamicColors(true)
+	scw.values.SetTitle(" [[::b]1[::-] mine | [::b]2[::-] base | [::b]3[::-] remote] ")
+
+	scw.list.EnableSecText()
+	scw.list.List().SetDoneFunc(func() {
+		// back to editing, my edits are kept
+		scw.tui.TogglePage(constants.ViewSecretData)
+	})
+	scw.list.List().SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		scw.showValues(index)
+	})
+	scw.AddItem(scw.list.List(), 0, 1, true)
+	scw.AddItem(scw.values, 0, 2, false)
+	scw.defineEvents()
+	return scw
+}
+
+func (scw *SecretConflictView) defineEvents() {
+	scw.list.List().SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Rune() == constants.TakeMine:
+			scw.choose(sideMine)
+		case event.Rune() == constants.TakeBase:
+			scw.choose(sideBase)
+		case event.Rune() == constants.TakeRemote:
+			scw.choose(sideRemote)
+		case event.Rune() == constants.Reveal:
+			scw.reveal = !scw.reveal
+			scw.showValues(scw.list.List().GetCurrentItem())
+		case event.Key() == tcell.KeyCtrlS:
+			scw.saveMerged()
+		case event.Key() == tcell.KeyCtrlO:
+			scw.overwrite()
+		case event.Key() == tcell.KeyCtrlD:
+			scw.discard()
+		default:
+			return event
+		}
+		return nil
+	})
+}
+
+func (scw *SecretConflictView) Hydrate(data ...interface{}) error {
+	scw.secretPath = data[0].(string)
+	scw.secretEng = data[1].(string)
+	scw.base = data[2].(map[string]vault.SecretValue)
+	scw.mine = data[3].(map[string]vault.SecretValue)
+	scw.baseVersion = data[4].(int)
+	scw.secretName = utils.GetChildPath(scw.secretPath)
+	scw.reveal = false
+	return scw.merge()
+}
+
+// merge compares base and my edits with current remote version
+func (scw *SecretConflictView) merge() error {
+	remote, metadata, err := scw.tui.vault.ReadKvSecret(scw.secretEng, scw.secretPath)
+	if err != nil {
+		return err
+	}
+	scw.remoteVersion, _ = strconv.Atoi(metadata["version"])
+	scw.keys = threeWayMerge(scw.base, scw.mine, remote)
+	scw.refreshTitle()
+	scw.PopulateList()
+	return nil
+}
+
+func (scw *SecretConflictView) refreshTitle() {
+	scw.list.SetTitle(fmt.Sprintf("[%v[::b] %v[::-], %s[::b] v%d -> v%d[::-], %s[::b] %d[::-]]", "Conflict:", scw.secretName, "Ver:", scw.baseVersion, scw.remoteVersion, "Unresolved:", unresolvedConflicts(scw.keys)))
+}
+
+func (scw *SecretConflictView) PopulateList() {
+	current := scw.list.List().GetCurrentItem()
+	scw.list.Clear()
+	for _, mk := range scw.keys {
+		scw.list.Add(mk.key, scw.keyStatus(mk), nil)
+	}
+	if current < len(scw.keys) {
+		scw.list.List().SetCurrentItem(current)
+	}
+	scw.showValues(scw.list.List().GetCurrentItem())
+}
+
+func (scw *SecretConflictView) keyStatus(mk *mergeKey) string {
+	side := map[mergeSide]string{sideMine: "mine", sideBase: "base", sideRemote: "remote"}[mk.choice]
+	switch {
+	case mk.conflict && !mk.resolved:
+		return colorfulPrint(fmt.Sprintf("! conflict, using %s", side), tcell.ColorDarkRed)
+	case mk.conflict:
+		return colorfulPrint(fmt.Sprintf("resolved, using %s", side), tcell.ColorGreen)
+	case !sameValue(mk.mine, mk.base) || !sameValue(mk.remote, mk.base):
+		return colorfulPrint(fmt.Sprintf("changed, using %s", side), tcell.ColorDarkOrange)
+	}
+	return colorfulPrint("unchanged", tcell.ColorGray)
+}
+
+func (scw *SecretConflictView) showValues(index int) {
+	scw.values.Clear()
+	if index < 0 || index >= len(scw.keys) {
+		return
+	}
+	mk := scw.keys[index]
+	for _, side := range []struct {
+		name  string
+		value *vault.SecretValue
+		side  mergeSide
+	}{
+		{fmt.Sprintf("1 mine (edited v%d)", scw.baseVersion), mk.mine, sideMine},
+		{fmt.Sprintf("2 base (v%d)", scw.baseVersion), mk.base, sideBase},
+		{fmt.Sprintf("3 remote (v%d)", scw.remoteVersion), mk.remote, sideRemote},
+	} {
+		title := side.name
+		if side.side == mk.choice {
+			title += " <- selected"
+		}
+		fmt.Fprintf(scw.values, "%s\n", colorfulPrint(title, tcell.ColorLime))
+		switch {
+		case side.value == nil:
+			fmt.Fprintf(scw.values, "%s\n\n", "<not present>")
+		case scw.reveal:
+			fmt.Fprintf(scw.values, "(%s)\n%s\n\n", side.value.Type, tview.Escape(side.value.Text))
+		default:
+			fmt.Fprintf(scw.values, "%s (%s, %s)\n\n", constants.Mask, side.value.Type, shortHash(getValueHash(*side.value)))
+		}
+	}
+}
+
+func (scw *SecretConflictView) choose(side mergeSide) {
+	i := scw.list.List().GetCurrentItem()
+	if i < 0 || i >= len(scw.keys) {
+		return
+	}
+	scw.keys[i].choose(side)
+	scw.refreshTitle()
+	scw.PopulateList()
+}
+
+func (scw *SecretConflictView) saveMerged() {
+	if n := unresolvedConflicts(scw.keys); n > 0 {
+		scw.tui.ShowStatusAndContinue(fmt.Sprintf("%d conflicts are not resolved (use 1/2/3)", n), ErrStatus)
+		return
+	}
+	scw.write(mergedSecret(scw.keys))
+}
+
+func (scw *SecretConflictView) overwrite() {
+	scw.tui.ShowConfirm(fmt.Sprintf("Overwrite version %d of '%s' with your edits?", scw.remoteVersion, scw.secretName), func() {
+		scw.write(scw.mine)
+	})
+}
+
+// discard drops my edits and opens current remote version
+func (scw *SecretConflictView) discard() {
+	scw.tui.ShowConfirm(fmt.Sprintf("Discard your edits of '%s'?", scw.secretName), func() {
+		scw.list.Clear()
+		scw.tui.ShowSecretDataView(scw.secretPath, scw.secretEng)
+	})
+}
+
+func (scw *SecretConflictView) write(secret map[string]vault.SecretValue) {
+	if len(secret) == 0 {
+		scw.tui.ShowStatusAndContinue("Secret must have at least one key", ErrStatus)
+		return
+	}
+	data, err := vault.SecretData(secret)
+	if err != nil {
+		scw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	err = scw.tui.vault.WriteKvSecretCas(scw.secretEng, scw.secretPath, data, scw.remoteVersion)
+	if errors.Is(err, vault.ErrCasConflict) {
+		// secret was changed again, my edits are compared with the newest version (base stays the same),
+		// choices are kept for keys which were not changed again
+		previous, previousVersion := scw.keys, scw.remoteVersion
+		if err := scw.merge(); err != nil {
+			scw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		dropped := restoreChoices(scw.keys, previous)
+		scw.refreshTitle()
+		scw.PopulateList()
+		scw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret was changed again (v%d -> v%d), base is still v%d, %d of your choices dropped (keys changed again)",
+			previousVersion, scw.remoteVersion, scw.baseVersion, dropped), ErrStatus)
+		return
+	}
+	if err != nil {
+		scw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	scw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret '%s' updated successfuly", scw.secretName), SuccessStatus)
+	scw.list.Clear()
+	scw.tui.ShowSecretDataView(scw.secretPath, scw.secretEng)
+}
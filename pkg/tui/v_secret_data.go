@@ -1,15 +1,26 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"vaultview/pkg/constants"
 	"vaultview/pkg/utils"
+	"vaultview/pkg/vault"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"golang.design/x/clipboard"
 )
 
+type SecretDataViewI interface {
+	View
+	HydrateDiff(secret, engine string, from, to int) error
+	SelectKey(key string)
+}
+
 type SecretMetadata struct {
 	version      string
 	created_time string
@@ -20,11 +31,20 @@ type SecretDataView struct {
 	tui                      *Tui
 	list                     *List
 	secret                   *tview.TextView
+	diffView                 *tview.Flex
+	diffOld, diffNew         *tview.TextView
 	editor                   *tview.TextArea
+	document                 *documentEditor
 	currentKey, secretName   string
 	secretEng, secretPath    string
-	keySecret, editKeySecret map[string]string
+	keySecret, editKeySecret map[string]vault.SecretValue
 	metadata                 SecretMetadata
+	kvVersion                int
+	// version opened from the history, 0 means latest (editable) version
+	version int
+	// diff mode, secret of version diffFrom is compared with version
+	diffFrom    int
+	diffSecrets map[string]vault.SecretValue
 }
 
 func NewSecretDataView(tui *Tui) *SecretDataView {
@@ -35,19 +55,27 @@ func NewSecretDataView(tui *Tui) *SecretDataView {
 	}
 
 	sdw.secret = sdw.initSecret()
+	sdw.diffView = sdw.initDiff()
 	sdw.editor = sdw.initEditor()
+	sdw.document = newDocumentEditor(sdw.saveDocument, sdw.closeDocument)
 
 	sdw.list.EnableSecText()
 	sdw.list.List().SetDoneFunc(func() {
 		sdw.list.Clear()
+		if sdw.isReadOnly() {
+			sdw.tui.TogglePage(constants.ViewSecretVersions)
+			return
+		}
 		sdw.tui.TogglePage(constants.ViewSecrets)
 	})
-	sdw.list.List().SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+	sdw.list.SetChangedFunc(func(mainText string) {
 		sdw.currentKey = mainText
 	})
 	sdw.AddItem(sdw.list.List(), 0, 3, true)
 	sdw.AddItem(sdw.secret, 0, 0, false)
+	sdw.AddItem(sdw.diffView, 0, 0, false)
 	sdw.AddItem(sdw.editor, 0, 0, false)
+	sdw.AddItem(sdw.document, 0, 0, false)
 	sdw.defineEvents()
 	return sdw
 }
@@ -60,16 +88,48 @@ func (sdw *SecretDataView) initSecret() *tview.TextView {
 	s.SetDynamicColors(true)
 	s.SetTitle(fmt.Sprint(" [[::b]Preview Mode[::-]] "))
 	s.SetDoneFunc(func(key tcell.Key) {
-		sdw.list.List().SetTitle(sdw.getFancyTitle())
-		sdw.secret.Clear()
-		sdw.tui.App.SetFocus(sdw.list.List())
-		sdw.ResizeItem(sdw.secret, 0, 0)
-		sdw.ResizeItem(sdw.editor, 0, 0)
-		sdw.ResizeItem(sdw.list.List(), 0, 3)
+		sdw.closePreview()
 	})
 	return s
 }
 
+// initDiff creates side by side preview of the key in diff mode, old version on the left
+func (sdw *SecretDataView) initDiff() *tview.Flex {
+	sdw.diffOld = tview.NewTextView()
+	sdw.diffNew = tview.NewTextView()
+	for _, pane := range []*tview.TextView{sdw.diffOld, sdw.diffNew} {
+		pane.SetBorder(true)
+		pane.SetWrap(true)
+		pane.SetDynamicColors(true)
+		pane.SetDoneFunc(func(key tcell.Key) {
+			sdw.closePreview()
+		})
+		pane.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			if event.Key() == tcell.KeyTab {
+				// tab is used to switch between the keys
+				sdw.list.NextItem()
+				sdw.revealSecret()
+				return nil
+			}
+			return event
+		})
+	}
+	return tview.NewFlex().
+		AddItem(sdw.diffOld, 0, 1, false).
+		AddItem(sdw.diffNew, 0, 1, true)
+}
+
+// closePreview hides value preview (or diff) and returns focus to the keys
+func (sdw *SecretDataView) closePreview() {
+	sdw.list.SetRawTitle(sdw.getFancyTitle())
+	sdw.secret.Clear()
+	sdw.tui.App.SetFocus(sdw.list.List())
+	sdw.ResizeItem(sdw.secret, 0, 0)
+	sdw.ResizeItem(sdw.diffView, 0, 0)
+	sdw.ResizeItem(sdw.editor, 0, 0)
+	sdw.ResizeItem(sdw.list.List(), 0, 3)
+}
+
 func (sdw *SecretDataView) initEditor() *tview.TextArea {
 	s := tview.NewTextArea()
 	s.SetBorder(true)
@@ -78,11 +138,18 @@ func (sdw *SecretDataView) initEditor() *tview.TextArea {
 	s.SetTitle(fmt.Sprint(" [[::b]Edit Mode[::-]] "))
 	s.SetWrap(true)
 	s.SetChangedFunc(func() {
-		sdw.editKeySecret[sdw.currentKey] = sdw.editor.GetText()
+		if sdw.editKeySecret == nil || sdw.currentKey == "" {
+			// editor is cleared without secret being edited (e.g. state is cleared)
+			return
+		}
+		v := sdw.editKeySecret[sdw.currentKey]
+		v.Text = sdw.editor.GetText()
+		sdw.editKeySecret[sdw.currentKey] = v
+		sdw.validateEditor(v)
 	})
 	s.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEsc {
-			sdw.list.List().SetTitle(sdw.getFancyTitle())
+			sdw.list.SetRawTitle(sdw.getFancyTitle())
 			sdw.secret.Clear()
 			sdw.tui.App.SetFocus(sdw.list.List())
 			sdw.ResizeItem(sdw.secret, 0, 0)
@@ -117,6 +184,27 @@ func (sdw *SecretDataView) defineEvents() {
 		} else if event.Key() == tcell.KeyCtrlS {
 			sdw.SaveSecret()
 			return nil
+		} else if event.Rune() == constants.History {
+			sdw.showHistory()
+			return nil
+		} else if event.Rune() == constants.AddKey {
+			sdw.addKey()
+			return nil
+		} else if event.Rune() == constants.RenameKey {
+			sdw.renameKey()
+			return nil
+		} else if event.Key() == tcell.KeyDelete {
+			sdw.removeKey()
+			return nil
+		} else if event.Rune() == constants.EditJSON {
+			sdw.openDocument(vault.FormatJSON)
+			return nil
+		} else if event.Rune() == constants.EditYAML {
+			sdw.openDocument(vault.FormatYAML)
+			return nil
+		} else if event.Rune() == constants.Filter {
+			sdw.tui.ShowFilter(sdw.list)
+			return nil
 		}
 		return event
 	})
@@ -137,10 +225,31 @@ func (sdw *SecretDataView) defineEvents() {
 	})
 }
 
+func (sdw *SecretDataView) isReadOnly() bool {
+	return sdw.version != 0
+}
+
+func (sdw *SecretDataView) isDiff() bool {
+	return sdw.diffFrom != 0
+}
+
+func (sdw *SecretDataView) showHistory() {
+	if sdw.kvVersion == vault.KvV1 {
+		sdw.tui.ShowStatusAndContinue("kv v1 secrets are not versioned", InfoStatus)
+		return
+	}
+	sdw.tui.ShowSecretVersionsView(sdw.secretPath, sdw.secretEng)
+}
+
 func (sdw *SecretDataView) activateEditor() {
+	if sdw.isReadOnly() {
+		sdw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is read-only", sdw.version), InfoStatus)
+		return
+	}
 	s := sdw.editKeySecret[sdw.currentKey]
-	sdw.list.List().SetTitle(sdw.getFancyTitleShort())
-	sdw.editor.SetText(s, false)
+	sdw.list.SetRawTitle(sdw.getFancyTitleShort())
+	sdw.editor.SetText(s.Text, false)
+	sdw.validateEditor(s)
 	sdw.ResizeItem(sdw.list.List(), 0, 1)
 	sdw.ResizeItem(sdw.secret, 0, 0)
 	sdw.ResizeItem(sdw.editor, 0, 3)
@@ -150,8 +259,16 @@ func (sdw *SecretDataView) activateEditor() {
 func (sdw *SecretDataView) revealSecret() {
 	sdw.secret.Clear()
 	s := sdw.keySecret[sdw.currentKey]
-	sdw.list.List().SetTitle(sdw.getFancyTitleShort())
-	fmt.Fprintf(sdw.secret, "%s", s)
+	sdw.list.SetRawTitle(sdw.getFancyTitleShort())
+	if sdw.isDiff() {
+		sdw.revealDiff()
+		return
+	} else if _, ok := sdw.keySecret[sdw.currentKey]; !ok {
+		// key is added, but not saved yet
+		fmt.Fprintf(sdw.secret, "%s", sdw.editKeySecret[sdw.currentKey].Text)
+	} else {
+		fmt.Fprintf(sdw.secret, "%s", s.Text)
+	}
 	sdw.ResizeItem(sdw.list.List(), 0, 1)
 	sdw.ResizeItem(sdw.editor, 0, 0)
 	sdw.ResizeItem(sdw.secret, 0, 3)
@@ -161,7 +278,21 @@ func (sdw *SecretDataView) revealSecret() {
 func (sdw *SecretDataView) Hydrate(data ...interface{}) error {
 	sdw.secretPath = data[0].(string)
 	sdw.secretEng = data[1].(string)
-	secrets, metadata, err := sdw.tui.vault.ReadKvSecret(sdw.secretEng, sdw.secretPath)
+	sdw.version = 0
+	sdw.diffFrom = 0
+	sdw.diffSecrets = nil
+	if len(data) > 2 {
+		sdw.version = data[2].(int)
+	}
+	kvVersion, err := sdw.tui.vault.KvVersion(sdw.secretEng)
+	if err != nil {
+		sdw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		sdw.list.Clear()
+		sdw.tui.TogglePage(constants.ViewSecrets)
+		return err
+	}
+	sdw.kvVersion = kvVersion
+	secrets, metadata, err := sdw.tui.vault.ReadKvSecretVersion(sdw.secretEng, sdw.secretPath, sdw.version)
 	if err != nil {
 		sName := utils.GetChildPath(sdw.secretPath)
 		sdw.tui.ShowStatusAndContinue(fmt.Sprintf("secret '%s' does not exist: %v", sName, err), ErrStatus)
@@ -173,37 +304,135 @@ func (sdw *SecretDataView) Hydrate(data ...interface{}) error {
 		version:      metadata["version"],
 		created_time: formatDate(metadata["created_time"]),
 	}
-	sdw.list.List().SetTitle(sdw.getFancyTitle())
+	sdw.list.SetRawTitle(sdw.getFancyTitle())
 	sdw.PopulateList(secrets)
 	return nil
 }
 
+// HydrateDiff shows secret in diff mode, keys of version 'to' are compared with version 'from'
+func (sdw *SecretDataView) HydrateDiff(secret, engine string, from, to int) error {
+	fromSecrets, _, err := sdw.tui.vault.ReadKvSecretVersion(engine, secret, from)
+	if err != nil {
+		return err
+	}
+	toSecrets, metadata, err := sdw.tui.vault.ReadKvSecretVersion(engine, secret, to)
+	if err != nil {
+		return err
+	}
+	sdw.secretPath = secret
+	sdw.secretEng = engine
+	sdw.secretName = utils.GetChildPath(secret)
+	sdw.kvVersion = vault.KvV2
+	sdw.version = to
+	sdw.diffFrom = from
+	sdw.metadata = SecretMetadata{
+		version:      metadata["version"],
+		created_time: formatDate(metadata["created_time"]),
+	}
+	sdw.list.SetRawTitle(sdw.getFancyTitle())
+	sdw.keySecret = toSecrets
+	sdw.editKeySecret = make(map[string]vault.SecretValue)
+	sdw.diffSecrets = fromSecrets
+	sdw.list.Clear()
+	for _, d := range diffSecrets(fromSecrets, toSecrets) {
+		sdw.list.Add(d.key, d.String(), nil)
+	}
+	return nil
+}
+
+func (sdw *SecretDataView) revealDiff() {
+	from, inFrom := sdw.diffSecrets[sdw.currentKey]
+	to, inTo := sdw.keySecret[sdw.currentKey]
+	sdw.diffOld.SetTitle(fmt.Sprintf(" [%s[::b] v%d[::-]] ", "Ver:", sdw.diffFrom))
+	sdw.diffNew.SetTitle(fmt.Sprintf(" [%s[::b] v%d[::-]] ", "Ver:", sdw.version))
+	sdw.diffOld.SetText(diffPaneText(from, inFrom, to, inTo, tcell.ColorRed)).ScrollToBeginning()
+	sdw.diffNew.SetText(diffPaneText(to, inTo, from, inFrom, tcell.ColorGreen)).ScrollToBeginning()
+	sdw.ResizeItem(sdw.list.List(), 0, 1)
+	sdw.ResizeItem(sdw.editor, 0, 0)
+	sdw.ResizeItem(sdw.secret, 0, 0)
+	sdw.ResizeItem(sdw.diffView, 0, 3)
+	sdw.tui.App.SetFocus(sdw.diffNew)
+}
+
+// openDocument opens whole secret as single json or yaml document
+func (sdw *SecretDataView) openDocument(format vault.DocumentFormat) {
+	if sdw.isReadOnly() {
+		sdw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is read-only", sdw.version), InfoStatus)
+		return
+	}
+	doc, err := vault.SecretDocument(sdw.editKeySecret, format)
+	if err != nil {
+		sdw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	sdw.document.open(doc, format)
+	sdw.ResizeItem(sdw.list.List(), 0, 0)
+	sdw.ResizeItem(sdw.secret, 0, 0)
+	sdw.ResizeItem(sdw.editor, 0, 0)
+	sdw.ResizeItem(sdw.document, 0, 1)
+	sdw.tui.App.SetFocus(sdw.document)
+}
+
+func (sdw *SecretDataView) ClearState() {
+	sdw.list.Clear()
+	sdw.secret.Clear()
+	sdw.editor.SetText("", false)
+	sdw.ResizeItem(sdw.secret, 0, 0)
+	sdw.ResizeItem(sdw.diffView, 0, 0)
+	sdw.ResizeItem(sdw.editor, 0, 0)
+	sdw.ResizeItem(sdw.document, 0, 0)
+	sdw.ResizeItem(sdw.list.List(), 0, 3)
+	sdw.currentKey, sdw.secretName = "", ""
+	sdw.secretEng, sdw.secretPath = "", ""
+	sdw.keySecret, sdw.editKeySecret = nil, nil
+	sdw.metadata = SecretMetadata{}
+	sdw.kvVersion, sdw.version, sdw.diffFrom = 0, 0, 0
+	sdw.diffSecrets = nil
+}
+
+func (sdw *SecretDataView) closeDocument() {
+	sdw.ResizeItem(sdw.document, 0, 0)
+	sdw.ResizeItem(sdw.list.List(), 0, 3)
+	sdw.tui.App.SetFocus(sdw.list.List())
+}
+
+// saveDocument replaces edited keys with the parsed document and saves the secret
+func (sdw *SecretDataView) saveDocument(secret map[string]vault.SecretValue) {
+	sdw.editKeySecret = secret
+	sdw.closeDocument()
+	sdw.refreshKeys("")
+	sdw.SaveSecret()
+}
+
 func (sdw *SecretDataView) CopyToClipboard() {
 	err := clipboard.Init()
 	if err != nil {
 		sdw.tui.ShowStatusAndContinue(fmt.Sprintf("Copy to clipboard error: %s", err.Error()), ErrStatus)
 	}
 	s := sdw.keySecret[sdw.currentKey]
-	clipboard.Write(clipboard.FmtText, []byte(s))
+	clipboard.Write(clipboard.FmtText, []byte(s.Text))
 	sdw.tui.ShowStatusAndContinue("Copied to clipboard", InfoStatus)
 }
 
 func (sdw *SecretDataView) SaveSecret() {
-	hasChanged := false
-	for k, v := range sdw.keySecret {
-		currentHash := getHash(v)
-		newHash := getHash(sdw.editKeySecret[k])
-		if currentHash != newHash {
-			hasChanged = true
-			break
-		}
+	if sdw.isReadOnly() {
+		sdw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is read-only", sdw.version), InfoStatus)
+		return
+	}
+	if len(sdw.editKeySecret) == 0 {
+		sdw.tui.ShowStatusAndContinue("Secret must have at least one key", ErrStatus)
+		return
 	}
-	if hasChanged {
-		sdwKeySecretAny := make(map[string]any)
-		for k, v := range sdw.editKeySecret {
-			sdwKeySecretAny[k] = v
+	if sdw.hasChanged() {
+		sdwKeySecretAny, err := vault.SecretData(sdw.editKeySecret)
+		if err != nil {
+			sdw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
 		}
-		if err := sdw.tui.vault.WriteKv2Secret(sdw.secretEng, sdw.secretPath, sdwKeySecretAny); err != nil {
+		err = sdw.writeSecret(sdwKeySecretAny)
+		if errors.Is(err, vault.ErrCasConflict) {
+			sdw.showConflict()
+		} else if err != nil {
 			sdw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
 		} else {
 			sdw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret '%s' updated successfuly", sdw.secretName), SuccessStatus)
@@ -220,20 +449,192 @@ func (sdw *SecretDataView) SaveSecret() {
 	}
 }
 
+// writeSecret writes kv v2 secret with check-and-set on version loaded in Hydrate
+func (sdw *SecretDataView) writeSecret(data map[string]any) error {
+	cas, err := strconv.Atoi(sdw.metadata.version)
+	if sdw.kvVersion != vault.KvV2 || err != nil {
+		return sdw.tui.vault.WriteKvSecret(sdw.secretEng, sdw.secretPath, data)
+	}
+	return sdw.tui.vault.WriteKvSecretCas(sdw.secretEng, sdw.secretPath, data, cas)
+}
+
+func (sdw *SecretDataView) showConflict() {
+	cas, _ := strconv.Atoi(sdw.metadata.version)
+	base := make(map[string]vault.SecretValue)
+	mine := make(map[string]vault.SecretValue)
+	for k, v := range sdw.keySecret {
+		base[k] = v
+	}
+	for k, v := range sdw.editKeySecret {
+		mine[k] = v
+	}
+	sdw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret '%s' was changed since version %d", sdw.secretName, cas), ErrStatus)
+	sdw.tui.ShowSecretConflictView(sdw.secretPath, sdw.secretEng, base, mine, cas)
+}
+
+// hasChanged detects changed, added and removed keys
+func (sdw *SecretDataView) hasChanged() bool {
+	if len(sdw.keySecret) != len(sdw.editKeySecret) {
+		return true
+	}
+	for k, v := range sdw.keySecret {
+		newValue, ok := sdw.editKeySecret[k]
+		if !ok {
+			return true
+		}
+		if !v.Equal(newValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func (sdw *SecretDataView) addKey() {
+	if sdw.isReadOnly() {
+		sdw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is read-only", sdw.version), InfoStatus)
+		return
+	}
+	var dialog *InputDialog
+	dialog = NewInputDialog("[Add Key]", "Key: ", "", func(key string) {
+		if err := sdw.validateKey(key); err != nil {
+			dialog.SetError(err)
+			return
+		}
+		sdw.tui.HideModal()
+		sdw.editKeySecret[key] = vault.SecretValue{Type: vault.TypeString}
+		sdw.refreshKeys(key)
+		sdw.activateEditor()
+	}, sdw.tui.HideModal)
+	sdw.tui.ShowModal(dialog)
+}
+
+func (sdw *SecretDataView) renameKey() {
+	if sdw.isReadOnly() {
+		sdw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is read-only", sdw.version), InfoStatus)
+		return
+	}
+	oldKey := sdw.currentKey
+	if _, ok := sdw.editKeySecret[oldKey]; !ok {
+		return
+	}
+	var dialog *InputDialog
+	dialog = NewInputDialog("[Rename Key]", "Key: ", oldKey, func(key string) {
+		if key == oldKey {
+			sdw.tui.HideModal()
+			return
+		}
+		if err := sdw.validateKey(key); err != nil {
+			dialog.SetError(err)
+			return
+		}
+		sdw.tui.HideModal()
+		sdw.editKeySecret[key] = sdw.editKeySecret[oldKey]
+		delete(sdw.editKeySecret, oldKey)
+		sdw.refreshKeys(key)
+	}, sdw.tui.HideModal)
+	sdw.tui.ShowModal(dialog)
+}
+
+func (sdw *SecretDataView) removeKey() {
+	if sdw.isReadOnly() {
+		sdw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is read-only", sdw.version), InfoStatus)
+		return
+	}
+	key := sdw.currentKey
+	if _, ok := sdw.editKeySecret[key]; !ok {
+		return
+	}
+	sdw.tui.ShowConfirm(fmt.Sprintf("Remove key '%s'?\nChange is saved with Ctrl+S.", key), func() {
+		delete(sdw.editKeySecret, key)
+		sdw.refreshKeys("")
+	})
+}
+
+func (sdw *SecretDataView) validateKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("key is required")
+	}
+	if _, ok := sdw.editKeySecret[key]; ok {
+		return fmt.Errorf("key '%s' already exists", key)
+	}
+	return nil
+}
+
+// refreshKeys populates list from edited keys, added and modified keys are marked
+func (sdw *SecretDataView) refreshKeys(selected string) {
+	keys := make([]string, 0, len(sdw.editKeySecret))
+	for k := range sdw.editKeySecret {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sdw.list.Clear()
+	for _, k := range keys {
+		secText := sdw.maskedValue(sdw.editKeySecret[k])
+		if v, ok := sdw.keySecret[k]; !ok {
+			secText += colorfulPrint(" (new)", tcell.ColorGreen)
+		} else if !v.Equal(sdw.editKeySecret[k]) {
+			secText += colorfulPrint(" (modified)", tcell.ColorDarkOrange)
+		}
+		sdw.list.Add(k, secText, nil)
+	}
+	sdw.list.Select(selected)
+}
+
 func (sdw *SecretDataView) getFancyTitle() string {
+	if sdw.kvVersion == vault.KvV1 {
+		// kv v1 secrets are not versioned
+		return fmt.Sprintf(" [%v[::b] %v[::-]] ", "Secret:", sdw.secretName)
+	}
+	if sdw.isDiff() {
+		return fmt.Sprintf(" [%v[::b] %v[::-], %s[::b] v%d -> v%d[::-]] ", "Secret:", sdw.secretName, "Diff:", sdw.diffFrom, sdw.version)
+	}
+	if sdw.isReadOnly() {
+		return fmt.Sprintf(" [%v[::b] %v[::-], %s[::b] %v[::-], %s[::b] %v[::-], [::b]read-only[::-]] ", "Secret:", sdw.secretName, "Ver:", sdw.metadata.version, "Created:", sdw.metadata.created_time)
+	}
 	return fmt.Sprintf(" [%v[::b] %v[::-], %s[::b] %v[::-], %s[::b] %v[::-]] ", "Secret:", sdw.secretName, "Ver:", sdw.metadata.version, "Created:", sdw.metadata.created_time)
 }
 
 func (sdw *SecretDataView) getFancyTitleShort() string {
+	if sdw.isDiff() {
+		return sdw.getFancyTitle()
+	}
+	if sdw.kvVersion == vault.KvV1 {
+		return fmt.Sprintf(" [%v[::b] %v[::-]] ", "Secret:", sdw.secretName)
+	}
 	return fmt.Sprintf(" [%v[::b] %v[::-], %s[::b] %v[::-]] ", "Secret:", sdw.secretName, "Ver:", sdw.metadata.version)
 }
 
-func (sdw *SecretDataView) PopulateList(secrets map[string]string) {
-	sdw.keySecret = make(map[string]string)
-	sdw.editKeySecret = make(map[string]string)
+func (sdw *SecretDataView) PopulateList(secrets map[string]vault.SecretValue) {
+	sdw.list.Clear()
+	sdw.keySecret = make(map[string]vault.SecretValue)
+	sdw.editKeySecret = make(map[string]vault.SecretValue)
 	for name, s := range secrets {
 		sdw.keySecret[name] = s
 		sdw.editKeySecret[name] = s
-		sdw.list.Add(name, constants.Mask, nil)
+		sdw.list.Add(name, sdw.maskedValue(s), nil)
+	}
+}
+
+// SelectKey makes key of the shown secret current
+func (sdw *SecretDataView) SelectKey(key string) {
+	sdw.list.Select(key)
+}
+
+// maskedValue is masked secret with type indicator
+func (sdw *SecretDataView) maskedValue(v vault.SecretValue) string {
+	if v.Type == vault.TypeString {
+		return constants.Mask
+	}
+	return fmt.Sprintf("%s %s", constants.Mask, colorfulPrint(fmt.Sprintf("(%s)", v.Type), tcell.ColorDarkCyan))
+}
+
+// validateEditor shows in the editor title whether text is valid for the type of the value
+func (sdw *SecretDataView) validateEditor(v vault.SecretValue) {
+	if err := v.Validate(); err != nil {
+		sdw.editor.SetBorderColor(tcell.ColorRed)
+		sdw.editor.SetTitle(fmt.Sprintf(" [[::b]Edit Mode[::-] (%s): %s] ", v.Type, tview.Escape(err.Error())))
+		return
 	}
+	sdw.editor.SetBorderColor(tcell.ColorLime)
+	sdw.editor.SetTitle(fmt.Sprintf(" [[::b]Edit Mode[::-] (%s)] ", v.Type))
 }
@@ -1,15 +1,24 @@
 package tui
 
 import (
+	"fmt"
 	"vaultview/pkg/constants"
+	"vaultview/pkg/vault"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+type SecretEngineViewI interface {
+	View
+	Engines() []vault.SecretEngine
+}
+
 type SecretEngineView struct {
 	*tview.Flex
-	tui  *Tui
-	list *List
+	tui     *Tui
+	list    *List
+	engines []vault.SecretEngine
 }
 
 func NewSecretEngineView(tui *Tui) *SecretEngineView {
@@ -19,6 +28,14 @@ func NewSecretEngineView(tui *Tui) *SecretEngineView {
 		list: NewList(constants.SecretEnginesTitle, tui),
 	}
 
+	secretView.list.EnableSecText()
+	secretView.list.List().SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == constants.Filter {
+			tui.ShowFilter(secretView.list)
+			return nil
+		}
+		return event
+	})
 	secretView.AddItem(secretView.list.List(), 0, 3, true)
 
 	return secretView
@@ -29,15 +46,31 @@ func (sew *SecretEngineView) Hydrate(data ...interface{}) error {
 	if err != nil {
 		return err
 	}
+	sew.list.Clear()
+	sew.engines = se
 	sew.PopulateList(se)
 	return nil
 }
 
-func (sew *SecretEngineView) PopulateList(se []string) {
+func (sew *SecretEngineView) ClearState() {
+	sew.list.Clear()
+	sew.engines = nil
+}
+
+// Engines returns engines listed by the last hydrate
+func (sew *SecretEngineView) Engines() []vault.SecretEngine {
+	return sew.engines
+}
+
+func (sew *SecretEngineView) PopulateList(se []vault.SecretEngine) {
 	for _, engine := range se {
 		selected := func() {
-			sew.tui.ShowSecretsView(engine)
+			if !engine.IsKv() {
+				sew.tui.ShowStatusAndContinue(fmt.Sprintf("secret engine type '%s' is not supported", engine.Type), InfoStatus)
+				return
+			}
+			sew.tui.ShowSecretsView(engine.Name)
 		}
-		sew.list.Add(engine, "", selected)
+		sew.list.Add(engine.Name, colorfulPrint(engine.Summary(), tcell.ColorGray), selected)
 	}
 }
@@ -0,0 +1,283 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"vaultview/pkg/constants"
+	"vaultview/pkg/utils"
+	"vaultview/pkg/vault"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type SecretVersionsView struct {
+	*tview.Flex
+	tui                   *Tui
+	list                  *List
+	secretEng, secretPath string
+	secretName            string
+	metadata              vault.KvMetadata
+	// version marked as the base for diff, 0 if none
+	diffFrom int
+}
+
+func (svw *SecretVersionsView) ClearState() {
+	svw.list.Clear()
+	svw.secretEng, svw.secretPath, svw.secretName = "", "", ""
+	svw.metadata = vault.KvMetadata{}
+	svw.diffFrom = 0
+}
+
+func NewSecretVersionsView(tui *Tui) *SecretVersionsView {
+	svw := &SecretVersionsView{
+		Flex: tview.NewFlex(),
+		tui:  tui,
+		list: NewList(constants.VersionsTitle, tui),
+	}
+
+	svw.list.EnableSecText()
+	svw.list.List().SetDoneFunc(func() {
+		svw.list.Clear()
+		svw.tui.ShowSecretDataView(svw.secretPath, svw.secretEng)
+	})
+	svw.AddItem(svw.list.List(), 0, 3, true)
+	svw.defineEvents()
+	return svw
+}
+
+func (svw *SecretVersionsView) defineEvents() {
+	svw.list.List().SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEnter {
+			svw.openVersion()
+			return nil
+		} else if event.Rune() == constants.Rollback {
+			svw.rollback()
+			return nil
+		} else if event.Rune() == constants.Diff {
+			svw.diff()
+			return nil
+		} else if event.Rune() == constants.Delete {
+			svw.deleteVersion()
+			return nil
+		} else if event.Rune() == constants.Undelete {
+			svw.undeleteVersion()
+			return nil
+		} else if event.Rune() == constants.Destroy {
+			svw.destroyVersion()
+			return nil
+		} else if event.Rune() == constants.Purge {
+			svw.purge()
+			return nil
+		} else if event.Key() == tcell.KeyCtrlR {
+			svw.refresh()
+			return nil
+		}
+		return event
+	})
+}
+
+func (svw *SecretVersionsView) Hydrate(data ...interface{}) error {
+	secretPath, secretEng := data[0].(string), data[1].(string)
+	if svw.secretEng != secretEng || svw.secretPath != secretPath {
+		// diff base belongs to the previous secret
+		svw.diffFrom = 0
+	}
+	svw.secretPath = secretPath
+	svw.secretEng = secretEng
+	svw.secretName = utils.GetChildPath(svw.secretPath)
+	metadata, err := svw.tui.vault.ReadKvSecretMetadata(svw.secretEng, svw.secretPath)
+	if err != nil {
+		return err
+	}
+	svw.metadata = metadata
+	svw.list.SetTitle(fmt.Sprintf("[%v[::b] %v[::-], %s[::b] %v[::-]]", "Versions:", svw.secretName, "Current:", metadata.CurrentVersion))
+	svw.PopulateList(metadata.Versions)
+	return nil
+}
+
+func (svw *SecretVersionsView) PopulateList(versions []vault.SecretVersion) {
+	current := svw.list.List().GetCurrentItem()
+	svw.list.Clear()
+	for _, v := range versions {
+		name := fmt.Sprintf("v%d", v.Version)
+		if v.Version == svw.metadata.CurrentVersion {
+			name += " (current)"
+		}
+		if v.Version == svw.diffFrom {
+			name += colorfulPrint(" [diff base]", tcell.ColorDarkOrange)
+		}
+		svw.list.Add(name, svw.versionDetails(v), nil)
+	}
+	if current < len(versions) {
+		svw.list.List().SetCurrentItem(current)
+	}
+}
+
+func (svw *SecretVersionsView) versionDetails(v vault.SecretVersion) string {
+	details := []string{fmt.Sprintf("created: %s", formatDate(v.CreatedTime))}
+	if v.IsDeleted() {
+		details = append(details, colorfulPrint(fmt.Sprintf("deleted: %s", formatDate(v.DeletionTime)), tcell.ColorDarkOrange))
+	}
+	if v.Destroyed {
+		details = append(details, colorfulPrint("destroyed", tcell.ColorDarkRed))
+	}
+	return strings.Join(details, " | ")
+}
+
+func (svw *SecretVersionsView) selectedVersion() (vault.SecretVersion, bool) {
+	i := svw.list.List().GetCurrentItem()
+	if i < 0 || i >= len(svw.metadata.Versions) {
+		return vault.SecretVersion{}, false
+	}
+	return svw.metadata.Versions[i], true
+}
+
+func (svw *SecretVersionsView) openVersion() {
+	v, ok := svw.selectedVersion()
+	if !ok {
+		return
+	}
+	if v.Destroyed || v.IsDeleted() {
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is deleted or destroyed", v.Version), InfoStatus)
+		return
+	}
+	svw.tui.ShowSecretDataVersionView(svw.secretPath, svw.secretEng, v.Version)
+}
+
+// diff marks first selected version as diff base, second selection opens diff
+func (svw *SecretVersionsView) diff() {
+	v, ok := svw.selectedVersion()
+	if !ok {
+		return
+	}
+	if v.Destroyed || v.IsDeleted() {
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is deleted or destroyed", v.Version), InfoStatus)
+		return
+	}
+	if svw.diffFrom == 0 || svw.diffFrom == v.Version {
+		if svw.diffFrom == v.Version {
+			svw.diffFrom = 0
+		} else {
+			svw.diffFrom = v.Version
+			svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d marked, select version to compare with and press 'd'", v.Version), InfoStatus)
+		}
+		svw.PopulateList(svw.metadata.Versions)
+		return
+	}
+	from, to := svw.diffFrom, v.Version
+	if from > to {
+		from, to = to, from
+	}
+	svw.diffFrom = 0
+	svw.PopulateList(svw.metadata.Versions)
+	svw.tui.ShowSecretDiffView(svw.secretPath, svw.secretEng, from, to)
+}
+
+func (svw *SecretVersionsView) rollback() {
+	v, ok := svw.selectedVersion()
+	if !ok {
+		return
+	}
+	if v.Version == svw.metadata.CurrentVersion {
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is already current version", v.Version), InfoStatus)
+		return
+	}
+	if v.Destroyed || v.IsDeleted() {
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is deleted or destroyed", v.Version), InfoStatus)
+		return
+	}
+	msg := fmt.Sprintf("Rollback '%s' to version %d?\nData of version %d will be written as the new current version.", svw.secretPath, v.Version, v.Version)
+	svw.tui.ShowConfirm(msg, func() {
+		if err := svw.tui.vault.RollbackKvSecret(svw.secretEng, svw.secretPath, v.Version); err != nil {
+			svw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret '%s' rolled back to version %d", svw.secretName, v.Version), SuccessStatus)
+		svw.refresh()
+	})
+}
+
+func (svw *SecretVersionsView) refresh() {
+	if err := svw.Hydrate(svw.secretPath, svw.secretEng); err != nil {
+		svw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+	}
+}
+
+func (svw *SecretVersionsView) deleteVersion() {
+	v, ok := svw.selectedVersion()
+	if !ok {
+		return
+	}
+	if v.Destroyed || v.IsDeleted() {
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is already deleted or destroyed", v.Version), InfoStatus)
+		return
+	}
+	msg := fmt.Sprintf("Soft delete version [::b]%d[::-] of secret\n[::b]%s/%s[::-]\nVersion can be restored with undelete.", v.Version, svw.secretEng, svw.secretPath)
+	svw.tui.ShowTypedConfirm("[Delete Version]", msg, svw.secretName, func() {
+		if err := svw.tui.vault.DeleteKvSecretVersions(svw.secretEng, svw.secretPath, []int{v.Version}); err != nil {
+			svw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("Version %d of '%s' deleted", v.Version, svw.secretName), SuccessStatus)
+		svw.refresh()
+	})
+}
+
+func (svw *SecretVersionsView) undeleteVersion() {
+	v, ok := svw.selectedVersion()
+	if !ok {
+		return
+	}
+	if v.Destroyed {
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is destroyed and cannot be restored", v.Version), InfoStatus)
+		return
+	}
+	if !v.IsDeleted() {
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is not deleted", v.Version), InfoStatus)
+		return
+	}
+	msg := fmt.Sprintf("Undelete version [::b]%d[::-] of secret\n[::b]%s/%s[::-]", v.Version, svw.secretEng, svw.secretPath)
+	svw.tui.ShowTypedConfirm("[Undelete Version]", msg, svw.secretName, func() {
+		if err := svw.tui.vault.UndeleteKvSecretVersions(svw.secretEng, svw.secretPath, []int{v.Version}); err != nil {
+			svw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("Version %d of '%s' restored", v.Version, svw.secretName), SuccessStatus)
+		svw.refresh()
+	})
+}
+
+func (svw *SecretVersionsView) destroyVersion() {
+	v, ok := svw.selectedVersion()
+	if !ok {
+		return
+	}
+	if v.Destroyed {
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("version %d is already destroyed", v.Version), InfoStatus)
+		return
+	}
+	msg := fmt.Sprintf("Permanently destroy version [::b]%d[::-] of secret\n[::b]%s/%s[::-]\nThis cannot be undone.", v.Version, svw.secretEng, svw.secretPath)
+	svw.tui.ShowTypedConfirm("[Destroy Version]", msg, svw.secretName, func() {
+		if err := svw.tui.vault.DestroyKvSecretVersions(svw.secretEng, svw.secretPath, []int{v.Version}); err != nil {
+			svw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("Version %d of '%s' destroyed", v.Version, svw.secretName), SuccessStatus)
+		svw.refresh()
+	})
+}
+
+// purge deletes metadata and all versions, user is returned to the secrets list
+func (svw *SecretVersionsView) purge() {
+	msg := fmt.Sprintf("Permanently delete metadata and [::b]all %d versions[::-] of secret\n[::b]%s/%s[::-]\nThis cannot be undone.", len(svw.metadata.Versions), svw.secretEng, svw.secretPath)
+	svw.tui.ShowTypedConfirm("[Delete All Versions]", msg, svw.secretName, func() {
+		if err := svw.tui.vault.DeleteKvSecretMetadata(svw.secretEng, svw.secretPath); err != nil {
+			svw.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+			return
+		}
+		svw.tui.ShowStatusAndContinue(fmt.Sprintf("Secret '%s' and all its versions deleted", svw.secretName), SuccessStatus)
+		svw.list.Clear()
+		svw.tui.TogglePageAndRefresh(constants.ViewSecrets)
+	})
+}
@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"vaultview/pkg/constants"
+	"vaultview/pkg/vault"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// treeNode is reference of the tree node, folders are listed when expanded for the first time
+type treeNode struct {
+	path   string
+	folder bool
+	loaded bool
+}
+
+// TreeView shows hierarchy of the engine (folders are expanded lazily) next to the preview of the selected secret
+type TreeView struct {
+	*tview.Flex
+	tui     *Tui
+	tree    *tview.TreeView
+	preview *tview.TextView
+	engine  string
+	reveal  bool
+	// preview is read in background, results of older reads are dropped
+	previewSeq int
+}
+
+func NewTreeView(tui *Tui) *TreeView {
+	tv := &TreeView{
+		Flex:    tview.NewFlex(),
+		tui:     tui,
+		tree:    tview.NewTreeView(),
+		preview: tview.NewTextView(),
+	}
+
+	tv.tree.SetBorder(true)
+	tv.tree.SetGraphicsColor(tcell.ColorGray)
+	tv.tree.SetSelectedFunc(tv.selected)
+	tv.tree.SetChangedFunc(tv.showPreview)
+	tv.tree.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			tv.tui.TogglePage(constants.ViewSecrets)
+		}
+	})
+	tv.tree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == constants.Reveal {
+			tv.reveal = !tv.reveal
+			tv.showPreview(tv.tree.GetCurrentNode())
+			return nil
+		} else if event.Key() == tcell.KeyCtrlR {
+			tv.reload(tv.tree.GetCurrentNode())
+			return nil
+		}
+		return event
+	})
+
+	tv.preview.SetBorder(true)
+	tv.preview.SetWrap(true)
+	tv.preview.SetDynamicColors(true)
+
+	tv.AddItem(tv.tree, 0, 1, true)
+	tv.AddItem(tv.preview, 0, 1, false)
+	return tv
+}
+
+// Hydrate shows tree of the engine with the path expanded
+func (tv *TreeView) Hydrate(data ...interface{}) error {
+	if len(data) < 2 {
+		return fmt.Errorf("error during type assertion")
+	}
+	engine, ok1 := data[0].(string)
+	p, ok2 := data[1].(string)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("error during type assertion")
+	}
+	tv.engine = engine
+	tv.reveal = false
+	tv.tree.SetTitle(fmt.Sprintf(" [%s[::b] %s[::-]] ", "Tree:", engine))
+
+	root := tview.NewTreeNode(engine + "/").
+		SetColor(tcell.ColorLime).
+		SetReference(&treeNode{folder: true})
+	if err := tv.expand(root); err != nil {
+		return err
+	}
+	tv.tree.SetRoot(root).SetCurrentNode(root)
+	// expand folders on the way to the path
+	node := root
+	for _, part := range strings.SplitAfter(p, "/") {
+		if part == "" {
+			break
+		}
+		child := childNode(node, part)
+		if child == nil || tv.expand(child) != nil {
+			break
+		}
+		node = child
+	}
+	tv.tree.SetCurrentNode(node)
+	tv.showPreview(node)
+	return nil
+}
+
+func childNode(node *tview.TreeNode, name string) *tview.TreeNode {
+	for _, child := range node.GetChildren() {
+		if child.GetText() == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// expand lists folder of the node once, cached lists of the secrets view are used
+func (tv *TreeView) expand(node *tview.TreeNode) error {
+	ref := node.GetReference().(*treeNode)
+	if !ref.loaded {
+		secrets, err := tv.tui.views[constants.ViewSecrets].(SecretViewI).CachedList(tv.engine, ref.path)
+		if err != nil {
+			return err
+		}
+		node.ClearChildren()
+		for _, s := range secrets {
+			child := tview.NewTreeNode(s).
+				SetReference(&treeNode{path: ref.path + s, folder: strings.HasSuffix(s, "/")})
+			if strings.HasSuffix(s, "/") {
+				child.SetColor(tcell.ColorLime).SetExpanded(false)
+			}
+			node.AddChild(child)
+		}
+		ref.loaded = true
+	}
+	node.SetExpanded(true)
+	return nil
+}
+
+// selected expands or collapses folder, secret is opened in the secret data view
+func (tv *TreeView) selected(node *tview.TreeNode) {
+	ref := node.GetReference().(*treeNode)
+	if !ref.folder {
+		if err := tv.tui.ChangePath(tv.engine + "/" + ref.path); err != nil {
+			tv.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		}
+		return
+	}
+	if node.IsExpanded() && ref.loaded {
+		node.SetExpanded(false)
+		return
+	}
+	if err := tv.expand(node); err != nil {
+		tv.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+	}
+	tv.showPreview(node)
+}
+
+// reload lists folder of the node again (secret preview is read again)
+func (tv *TreeView) reload(node *tview.TreeNode) {
+	if node == nil {
+		return
+	}
+	ref := node.GetReference().(*treeNode)
+	if !ref.folder {
+		tv.showPreview(node)
+		return
+	}
+	secrets, err := tv.tui.vault.ListKvSecrets(tv.engine, ref.path)
+	if err != nil {
+		tv.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+		return
+	}
+	tv.tui.views[constants.ViewSecrets].(SecretViewI).CacheList(tv.engine, ref.path, secrets)
+	ref.loaded = false
+	if err := tv.expand(node); err != nil {
+		tv.tui.ShowStatusAndContinue(err.Error(), ErrStatus)
+	}
+	tv.showPreview(node)
+}
+
+// showPreview shows content of the folder or secret, secret is read in background so moving
+// the cursor is not blocked by vault
+func (tv *TreeView) showPreview(node *tview.TreeNode) {
+	tv.preview.Clear()
+	tv.previewSeq++
+	if node == nil {
+		return
+	}
+	ref := node.GetReference().(*treeNode)
+	if ref.folder {
+		tv.preview.SetTitle(fmt.Sprintf(" [%s[::b] %s/%s[::-]] ", "Folder:", tv.engine, ref.path))
+		if !ref.loaded {
+			fmt.Fprint(tv.preview, colorfulPrint("press Enter to expand", tcell.ColorGray))
+			return
+		}
+		fmt.Fprintf(tv.preview, "%d items", len(node.GetChildren()))
+		return
+	}
+	seq, engine, reveal, vaultCli := tv.previewSeq, tv.engine, tv.reveal, tv.tui.vault
+	tv.preview.SetTitle(fmt.Sprintf(" [%s[::b] %s[::-]] ", "Secret:", ref.path))
+	fmt.Fprint(tv.preview, colorfulPrint("loading...", tcell.ColorGray))
+	go func() {
+		secret, metadata, err := vaultCli.ReadKvSecret(engine, ref.path)
+		tv.tui.App.QueueUpdateDraw(func() {
+			if seq != tv.previewSeq {
+				// cursor was moved meanwhile
+				return
+			}
+			tv.renderSecret(ref.path, secret, metadata, err, reveal)
+		})
+	}()
+}
+
+// renderSecret shows keys of the secret read for the preview, values are masked until revealed
+func (tv *TreeView) renderSecret(p string, secret map[string]vault.SecretValue, metadata map[string]string, err error, reveal bool) {
+	tv.preview.Clear()
+	if err != nil {
+		fmt.Fprint(tv.preview, colorfulPrint(tview.Escape(err.Error()), tcell.ColorRed))
+		return
+	}
+	title := fmt.Sprintf(" [%s[::b] %s[::-]] ", "Secret:", p)
+	if version, ok := metadata["version"]; ok {
+		title = fmt.Sprintf(" [%s[::b] %s[::-], %s[::b] %s[::-]] ", "Secret:", p, "Ver:", version)
+	}
+	tv.preview.SetTitle(title)
+	keys := make([]string, 0, len(secret))
+	for k := range secret {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := constants.Mask
+		if reveal {
+			value = tview.Escape(secret[k].Text)
+		}
+		fmt.Fprintf(tv.preview, "%s: %s\n", colorfulPrint(tview.Escape(k), tcell.ColorLime), value)
+	}
+	tv.preview.ScrollToBeginning()
+}
+
+func (tv *TreeView) ClearState() {
+	tv.previewSeq++
+	tv.tree.SetRoot(nil)
+	tv.preview.Clear()
+	tv.engine = ""
+}
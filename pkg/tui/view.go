@@ -3,3 +3,8 @@ package tui
 type View interface {
 	Hydrate(data ...interface{}) error
 }
+
+// StatefulView keeps data read from vault, the state is cleared when vault connection changes
+type StatefulView interface {
+	ClearState()
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
+)
+
+const (
+	AuthToken    = "token"
+	AuthUserpass = "userpass"
+	AuthLDAP     = "ldap"
+	AuthAppRole  = "approle"
+	AuthOIDC     = "oidc"
+)
+
+// AuthMethods lists login methods supported in configuration dialog
+var AuthMethods = []string{AuthToken, AuthUserpass, AuthLDAP, AuthAppRole, AuthOIDC}
+
+// Credentials used to login, Username/Password for userpass and ldap, RoleID/SecretID for approle,
+// Role for oidc
+type Credentials struct {
+	Username string
+	Password string
+	RoleID   string
+	SecretID string
+	Role     string
+}
+
+// Login exchanges credentials for a token using auth method mounted at mountPath
+// (method name is used when mountPath is empty), token is kept only by vault client
+func (v Vault) Login(method, mountPath string, creds Credentials) error {
+	if mountPath == "" {
+		mountPath = method
+	}
+	mountPath = strings.Trim(mountPath, "/")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	var (
+		resp *vault.Response[map[string]interface{}]
+		err  error
+	)
+	switch method {
+	case AuthUserpass:
+		resp, err = v.cli.Auth.UserpassLogin(ctx, creds.Username, schema.UserpassLoginRequest{
+			Password: creds.Password,
+		}, vault.WithMountPath(mountPath))
+	case AuthLDAP:
+		resp, err = v.cli.Auth.LdapLogin(ctx, creds.Username, schema.LdapLoginRequest{
+			Password: creds.Password,
+		}, vault.WithMountPath(mountPath))
+	case AuthAppRole:
+		resp, err = v.cli.Auth.AppRoleLogin(ctx, schema.AppRoleLoginRequest{
+			RoleId:   creds.RoleID,
+			SecretId: creds.SecretID,
+		}, vault.WithMountPath(mountPath))
+	default:
+		return fmt.Errorf("unsupported auth method '%s'", method)
+	}
+	if err != nil {
+		return fmt.Errorf("%s login failed: %w", method, err)
+	}
+	return v.setAuthToken(resp.Auth)
+}
+
+func (v Vault) setAuthToken(auth *vault.ResponseAuth) error {
+	if auth == nil || auth.ClientToken == "" {
+		return fmt.Errorf("login response does not contain token")
+	}
+	return v.cli.SetToken(auth.ClientToken)
+}
@@ -0,0 +1,69 @@
+package vault
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
+)
+
+// DeleteKvSecret soft deletes the latest version of kv v2 secret,
+// kv v1 secrets are not versioned and are deleted permanently
+func (v Vault) DeleteKvSecret(mountPath, secretPath string) error {
+	ver, err := v.KvVersion(mountPath)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	if ver == KvV1 {
+		_, err = v.cli.Secrets.KvV1Delete(ctx, secretPath, vault.WithMountPath(mountPath))
+		return err
+	}
+	_, err = v.cli.Secrets.KvV2Delete(ctx, secretPath, vault.WithMountPath(mountPath))
+	return err
+}
+
+func (v Vault) DeleteKvSecretVersions(mountPath, secretPath string, versions []int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	_, err := v.cli.Secrets.KvV2DeleteVersions(ctx, secretPath, schema.KvV2DeleteVersionsRequest{
+		Versions: toInt32(versions),
+	}, vault.WithMountPath(mountPath))
+	return err
+}
+
+func (v Vault) UndeleteKvSecretVersions(mountPath, secretPath string, versions []int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	_, err := v.cli.Secrets.KvV2UndeleteVersions(ctx, secretPath, schema.KvV2UndeleteVersionsRequest{
+		Versions: toInt32(versions),
+	}, vault.WithMountPath(mountPath))
+	return err
+}
+
+func (v Vault) DestroyKvSecretVersions(mountPath, secretPath string, versions []int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	_, err := v.cli.Secrets.KvV2DestroyVersions(ctx, secretPath, schema.KvV2DestroyVersionsRequest{
+		Versions: toInt32(versions),
+	}, vault.WithMountPath(mountPath))
+	return err
+}
+
+// DeleteKvSecretMetadata permanently deletes metadata and all versions of kv v2 secret
+func (v Vault) DeleteKvSecretMetadata(mountPath, secretPath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	_, err := v.cli.Secrets.KvV2DeleteMetadataAndAllVersions(ctx, secretPath, vault.WithMountPath(mountPath))
+	return err
+}
+
+func toInt32(versions []int) []int32 {
+	v32 := make([]int32, 0, len(versions))
+	for _, v := range versions {
+		v32 = append(v32, int32(v))
+	}
+	return v32
+}
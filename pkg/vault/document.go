@@ -0,0 +1,211 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentFormat is format used to edit whole secret as single document
+type DocumentFormat string
+
+const (
+	FormatJSON DocumentFormat = "json"
+	FormatYAML DocumentFormat = "yaml"
+)
+
+// maxYAMLValues limits number of values of yaml document with aliases expanded,
+// so small document with nested aliases cannot exhaust memory
+const maxYAMLValues = 100000
+
+// DocumentError is parse error of the secret document, line and column start from 1 (0 if unknown)
+type DocumentError struct {
+	Line, Column int
+	Msg          string
+}
+
+func (e *DocumentError) Error() string {
+	if e.Line == 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// SecretDocument renders secret as single json or yaml document
+func SecretDocument(secret map[string]SecretValue, format DocumentFormat) (string, error) {
+	data, err := SecretData(secret)
+	if err != nil {
+		return "", err
+	}
+	if format == FormatYAML {
+		for k, v := range data {
+			data[k] = normalizeNumber(v)
+		}
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	doc, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(doc), nil
+}
+
+// ParseSecretDocument parses json or yaml document, document must be an object
+func ParseSecretDocument(doc string, format DocumentFormat) (map[string]SecretValue, error) {
+	var data map[string]interface{}
+	if format == FormatYAML {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(doc), &node); err != nil {
+			return nil, yamlDocumentError(err)
+		}
+		val, err := newYAMLDecoder().value(&node)
+		if err != nil {
+			return nil, err
+		}
+		if val != nil {
+			m, ok := val.(map[string]interface{})
+			if !ok {
+				return nil, &DocumentError{Line: node.Line, Column: node.Column, Msg: "document must be an object"}
+			}
+			data = m
+		}
+	} else {
+		dec := json.NewDecoder(strings.NewReader(doc))
+		dec.UseNumber()
+		if err := dec.Decode(&data); err != nil {
+			return nil, jsonDocumentError(doc, err)
+		}
+		if dec.More() {
+			line, col := position(doc, dec.InputOffset())
+			return nil, &DocumentError{Line: line, Column: col, Msg: "unexpected data after the document"}
+		}
+	}
+	if data == nil {
+		return nil, &DocumentError{Msg: "document must be an object with at least one key"}
+	}
+	return secretValues(data)
+}
+
+func jsonDocumentError(doc string, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, col := position(doc, syntaxErr.Offset)
+		return &DocumentError{Line: line, Column: col, Msg: syntaxErr.Error()}
+	case errors.As(err, &typeErr):
+		line, col := position(doc, typeErr.Offset)
+		return &DocumentError{Line: line, Column: col, Msg: "document must be an object"}
+	}
+	return &DocumentError{Msg: err.Error()}
+}
+
+// yamlDecoder converts yaml nodes to json compatible values, aliases are expanded
+type yamlDecoder struct {
+	// anchors being expanded (alias referencing its own anchor is an error)
+	aliases map[*yaml.Node]bool
+	values  int
+}
+
+func newYAMLDecoder() *yamlDecoder {
+	return &yamlDecoder{aliases: make(map[*yaml.Node]bool)}
+}
+
+// value converts yaml node to json compatible value,
+// timestamps and other non json scalars are kept as strings
+func (d *yamlDecoder) value(node *yaml.Node) (any, error) {
+	d.values++
+	if d.values > maxYAMLValues {
+		return nil, &DocumentError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("document has more than %d values (aliases included)", maxYAMLValues)}
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return d.value(node.Content[0])
+	case yaml.AliasNode:
+		if d.aliases[node.Alias] {
+			return nil, &DocumentError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("alias '%s' references itself", node.Value)}
+		}
+		d.aliases[node.Alias] = true
+		defer delete(d.aliases, node.Alias)
+		return d.value(node.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{})
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k := node.Content[i]
+			if k.Kind != yaml.ScalarNode {
+				return nil, &DocumentError{Line: k.Line, Column: k.Column, Msg: "keys must be strings"}
+			}
+			v, err := d.value(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[k.Value] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := []interface{}{}
+		for _, n := range node.Content {
+			v, err := d.value(n)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		return s, nil
+	}
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return nil, &DocumentError{Line: node.Line, Column: node.Column, Msg: err.Error()}
+		}
+		return b, nil
+	case "!!int", "!!float":
+		var f any
+		if err := node.Decode(&f); err != nil {
+			return nil, &DocumentError{Line: node.Line, Column: node.Column, Msg: err.Error()}
+		}
+		n, err := json.Marshal(f)
+		if err != nil {
+			return nil, &DocumentError{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf("invalid number '%s'", node.Value)}
+		}
+		return json.Number(n), nil
+	}
+	return node.Value, nil
+}
+
+func yamlDocumentError(err error) error {
+	var line int
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	if _, scanErr := fmt.Sscanf(msg, "line %d:", &line); scanErr == nil {
+		msg = strings.TrimSpace(msg[strings.Index(msg, ":")+1:])
+		return &DocumentError{Line: line, Column: 1, Msg: msg}
+	}
+	return &DocumentError{Msg: msg}
+}
+
+// position converts byte offset to line and column
+func position(doc string, offset int64) (int, int) {
+	if offset > int64(len(doc)) {
+		offset = int64(len(doc))
+	}
+	before := doc[:offset]
+	line := strings.Count(before, "\n") + 1
+	col := int(offset) - strings.LastIndex(before, "\n")
+	return line, col
+}
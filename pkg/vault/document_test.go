@@ -0,0 +1,47 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSecretDocumentYAMLAliases(t *testing.T) {
+	secret, err := ParseSecretDocument("base: &b {user: admin}\ncopy: *b\n", FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secret["copy"].Text != secret["base"].Text {
+		t.Errorf("expected alias to expand to %q, got %q", secret["base"].Text, secret["copy"].Text)
+	}
+}
+
+func TestParseSecretDocumentYAMLSelfReferencingAlias(t *testing.T) {
+	for _, doc := range []string{
+		"a: &x [*x]\n",
+		"a: &x {b: *x}\n",
+	} {
+		_, err := ParseSecretDocument(doc, FormatYAML)
+		var docErr *DocumentError
+		if !errors.As(err, &docErr) {
+			t.Errorf("%q: expected document error, got %v", doc, err)
+		}
+	}
+}
+
+func TestParseSecretDocumentYAMLAliasBomb(t *testing.T) {
+	doc := `a: &a ["x", "x", "x", "x", "x", "x", "x", "x", "x", "x"]
+b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a, *a]
+c: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b, *b]
+d: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c, *c]
+e: &e [*d, *d, *d, *d, *d, *d, *d, *d, *d, *d]
+f: &f [*e, *e, *e, *e, *e, *e, *e, *e, *e, *e]
+g: &g [*f, *f, *f, *f, *f, *f, *f, *f, *f, *f]
+h: &h [*g, *g, *g, *g, *g, *g, *g, *g, *g, *g]
+i: &i [*h, *h, *h, *h, *h, *h, *h, *h, *h, *h]
+`
+	_, err := ParseSecretDocument(doc, FormatYAML)
+	var docErr *DocumentError
+	if !errors.As(err, &docErr) {
+		t.Errorf("expected document error, got %v", err)
+	}
+}
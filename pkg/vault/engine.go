@@ -0,0 +1,84 @@
+package vault
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SecretEngine describes mounted secret engine
+type SecretEngine struct {
+	Name        string
+	Type        string
+	Description string
+	Accessor    string
+	Options     map[string]string
+	Version     int
+	SealWrap    bool
+	Local       bool
+}
+
+func newSecretEngine(name string, mount map[string]interface{}) SecretEngine {
+	se := SecretEngine{
+		Name:    name,
+		Options: make(map[string]string),
+	}
+	se.Type, _ = mount["type"].(string)
+	se.Description, _ = mount["description"].(string)
+	se.Accessor, _ = mount["accessor"].(string)
+	se.SealWrap, _ = mount["seal_wrap"].(bool)
+	se.Local, _ = mount["local"].(bool)
+	if opts, ok := mount["options"].(map[string]interface{}); ok {
+		for k, v := range opts {
+			se.Options[k] = fmt.Sprintf("%v", v)
+		}
+	}
+	if ver, ok := kvVersionFromMount(mount["type"], mount["options"]); ok {
+		se.Version = ver
+	}
+	return se
+}
+
+// IsKv reports whether engine can be browsed as kv store
+func (se SecretEngine) IsKv() bool {
+	return se.Version == KvV1 || se.Version == KvV2
+}
+
+// Summary is short, one line description of the engine
+func (se SecretEngine) Summary() string {
+	parts := []string{}
+	if se.IsKv() {
+		parts = append(parts, fmt.Sprintf("type: %s v%d", se.Type, se.Version))
+	} else {
+		parts = append(parts, fmt.Sprintf("type: %s", se.Type))
+	}
+	if se.Description != "" {
+		parts = append(parts, se.Description)
+	}
+	if se.Accessor != "" {
+		parts = append(parts, fmt.Sprintf("accessor: %s", se.Accessor))
+	}
+	if opts := se.options(); opts != "" {
+		parts = append(parts, fmt.Sprintf("options: %s", opts))
+	}
+	if se.SealWrap {
+		parts = append(parts, "seal wrap")
+	}
+	if se.Local {
+		parts = append(parts, "local")
+	}
+	return strings.Join(parts, " | ")
+}
+
+// options are mount options sorted by name (kv version is already part of the type)
+func (se SecretEngine) options() string {
+	opts := make([]string, 0, len(se.Options))
+	for k, v := range se.Options {
+		if k == "version" && se.IsKv() {
+			continue
+		}
+		opts = append(opts, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(opts)
+	return strings.Join(opts, ",")
+}
@@ -0,0 +1,42 @@
+package vault
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SetNamespace switches all subsequent requests to the namespace, empty namespace is the root one.
+// Mount versions are forgotten as mounts differ between namespaces.
+func (v Vault) SetNamespace(namespace string) error {
+	if err := v.cli.SetNamespace(strings.Trim(namespace, "/")); err != nil {
+		return err
+	}
+	v.mounts.reset()
+	return nil
+}
+
+// ListNamespaces lists child namespaces of the current namespace (names end with '/')
+func (v Vault) ListNamespaces() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	s, err := v.cli.List(ctx, "sys/namespaces")
+	if err != nil {
+		if v.IsErrorStatus(err, http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	keys, ok := s.Data["keys"].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	namespaces := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if ns, ok := k.(string); ok {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, nil
+}
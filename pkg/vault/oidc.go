@@ -0,0 +1,155 @@
+package vault
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
+)
+
+const (
+	// OIDCListenAddr is default address of the loopback callback listener (same as vault cli uses)
+	OIDCListenAddr   = "localhost:8250"
+	oidcCallbackPath = "/oidc/callback"
+)
+
+// OIDCLogin runs vault oidc flow: auth url with redirect to local loopback listener is requested
+// from vault and handed to showURL, login is completed when identity provider redirects browser
+// to the listener (or fails when ctx is cancelled), callbacks with other state than the auth url
+// are ignored. Empty listenAddr means OIDCListenAddr.
+func (v Vault) OIDCLogin(ctx context.Context, mountPath, role, listenAddr string, showURL func(authURL string)) error {
+	if mountPath == "" {
+		mountPath = AuthOIDC
+	}
+	mountPath = strings.Trim(mountPath, "/")
+	if listenAddr == "" {
+		listenAddr = OIDCListenAddr
+	}
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return fmt.Errorf("oidc callback listener: %w", err)
+	}
+	defer listener.Close()
+
+	redirectURI, err := oidcRedirectURI(listenAddr, listener.Addr())
+	if err != nil {
+		return err
+	}
+	nonce, err := clientNonce()
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	resp, err := v.cli.Auth.JwtOidcRequestAuthorizationUrl(reqCtx, schema.JwtOidcRequestAuthorizationUrlRequest{
+		ClientNonce: nonce,
+		RedirectUri: redirectURI,
+		Role:        role,
+	}, vault.WithMountPath(mountPath))
+	cancel()
+	if err != nil {
+		return fmt.Errorf("oidc auth url: %w", err)
+	}
+	authURL, _ := resp.Data["auth_url"].(string)
+	if authURL == "" {
+		return fmt.Errorf("vault returned empty oidc auth url, check that '%s' is allowed redirect uri of role '%s'", redirectURI, role)
+	}
+
+	// state of the auth url identifies redirects of this login, other callbacks are ignored
+	state := ""
+	if u, err := url.Parse(authURL); err == nil {
+		state = u.Query().Get("state")
+	}
+
+	done := make(chan error, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		if state != "" && r.URL.Query().Get("state") != state {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "Vault login failed: state does not match, callback is ignored")
+			return
+		}
+		err := v.oidcCallback(ctx, mountPath, nonce, r)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, "Vault login failed: %s\n", err)
+		} else {
+			fmt.Fprintln(w, "Vault login successful, you can close this window and return to vaultview.")
+		}
+		select {
+		case done <- err:
+		default:
+		}
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(listener)
+	defer func() {
+		// let the browser receive the response before the listener is closed
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	showURL(authURL)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		return err
+	}
+}
+
+// oidcCallback exchanges code from identity provider redirect for vault token
+func (v Vault) oidcCallback(ctx context.Context, mountPath, nonce string, r *http.Request) error {
+	query := r.URL.Query()
+	if e := query.Get("error"); e != "" {
+		if desc := query.Get("error_description"); desc != "" {
+			e = fmt.Sprintf("%s: %s", e, desc)
+		}
+		return fmt.Errorf("identity provider: %s", e)
+	}
+	if query.Get("code") == "" {
+		return fmt.Errorf("callback does not contain authorization code")
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	resp, err := v.cli.Auth.JwtOidcCallback(reqCtx, nonce, query.Get("code"), query.Get("state"), vault.WithMountPath(mountPath))
+	if err != nil {
+		return fmt.Errorf("oidc callback: %w", err)
+	}
+	return v.setAuthToken(resp.Auth)
+}
+
+// oidcRedirectURI uses host from listenAddr and port of the listener (listenAddr may use port 0)
+func oidcRedirectURI(listenAddr string, addr net.Addr) (string, error) {
+	host, _, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return "", err
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	port := ""
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		port = strconv.Itoa(tcpAddr.Port)
+	}
+	return fmt.Sprintf("http://%s%s", net.JoinHostPort(host, port), oidcCallbackPath), nil
+}
+
+func clientNonce() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
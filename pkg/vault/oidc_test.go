@@ -0,0 +1,112 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"vaultview/pkg/config"
+)
+
+const (
+	testOIDCState = "state-1"
+	testOIDCToken = "s.oidc-token"
+)
+
+// fakeOIDCVault serves oidc auth_url and callback endpoints of the mount and token lookup
+func fakeOIDCVault(t *testing.T, mount string) (*httptest.Server, *string) {
+	redirectURI := new(string)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/"+mount+"/oidc/auth_url", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("auth_url request: %v", err)
+		}
+		*redirectURI, _ = req["redirect_uri"].(string)
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"auth_url": "https://idp.example.com/auth?state=" + testOIDCState},
+		})
+	})
+	mux.HandleFunc("/v1/auth/"+mount+"/oidc/callback", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != testOIDCState {
+			t.Errorf("callback with state %q reached vault", r.URL.Query().Get("state"))
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{},
+			"auth": map[string]any{"client_token": testOIDCToken},
+		})
+	})
+	mux.HandleFunc("/v1/auth/token/lookup-self", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != testOIDCToken {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"policies": []string{"default"}, "expire_time": "2030-01-01T00:00:00Z"},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, redirectURI
+}
+
+func callback(t *testing.T, redirectURI, state string) int {
+	res, err := http.Get(redirectURI + "?" + url.Values{"code": {"code-1"}, "state": {state}}.Encode())
+	if err != nil {
+		t.Errorf("callback: %v", err)
+		return 0
+	}
+	res.Body.Close()
+	return res.StatusCode
+}
+
+func TestOIDCLogin(t *testing.T) {
+	srv, redirectURI := fakeOIDCVault(t, "oidc")
+	svc, err := NewVault(srv.URL, "", "", config.TLSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	statuses := make(chan int, 2)
+	err = svc.(*Vault).OIDCLogin(ctx, "oidc", "dev", "127.0.0.1:0", func(authURL string) {
+		go func() {
+			// callback of other login is ignored, login keeps waiting for the right one
+			statuses <- callback(t, *redirectURI, "other-state")
+			statuses <- callback(t, *redirectURI, testOIDCState)
+		}()
+	})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if status := <-statuses; status != http.StatusBadRequest {
+		t.Errorf("expected mismatched state to be rejected with %d, got %d", http.StatusBadRequest, status)
+	}
+	if status := <-statuses; status != http.StatusOK {
+		t.Errorf("expected callback to succeed, got %d", status)
+	}
+	if _, err := svc.ReadTokenInfo(); err != nil {
+		t.Errorf("token of the login is not used: %v", err)
+	}
+}
+
+func TestOIDCLoginCancelled(t *testing.T) {
+	srv, _ := fakeOIDCVault(t, "oidc")
+	svc, err := NewVault(srv.URL, "", "", config.TLSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	err = svc.(*Vault).OIDCLogin(ctx, "oidc", "dev", "127.0.0.1:0", func(authURL string) {
+		cancel()
+	})
+	if err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
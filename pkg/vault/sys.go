@@ -0,0 +1,87 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AuthMount describes enabled auth method
+type AuthMount struct {
+	Path        string
+	Type        string
+	Description string
+	Accessor    string
+	Local       bool
+}
+
+// Summary is short, one line description of the auth method
+func (am AuthMount) Summary() string {
+	parts := []string{fmt.Sprintf("type: %s", am.Type)}
+	if am.Description != "" {
+		parts = append(parts, am.Description)
+	}
+	if am.Accessor != "" {
+		parts = append(parts, fmt.Sprintf("accessor: %s", am.Accessor))
+	}
+	if am.Local {
+		parts = append(parts, "local")
+	}
+	return strings.Join(parts, " | ")
+}
+
+func (v Vault) ReadAuthMethods() ([]AuthMount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	s, err := v.cli.System.AuthListEnabledMethods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mounts := []AuthMount{}
+	for path, mount := range s.Data {
+		m, ok := mount.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		am := AuthMount{Path: path}
+		am.Type, _ = m["type"].(string)
+		am.Description, _ = m["description"].(string)
+		am.Accessor, _ = m["accessor"].(string)
+		am.Local, _ = m["local"].(bool)
+		mounts = append(mounts, am)
+	}
+	sort.Slice(mounts, func(i, j int) bool {
+		return mounts[i].Path < mounts[j].Path
+	})
+	return mounts, nil
+}
+
+func (v Vault) ListPolicies() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	s, err := v.cli.System.PoliciesListAclPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies := s.Data.Keys
+	if len(policies) == 0 {
+		policies = s.Data.Policies
+	}
+	sort.Strings(policies)
+	return policies, nil
+}
+
+func (v Vault) ReadPolicy(name string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	s, err := v.cli.System.PoliciesReadAclPolicy(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if s.Data.Policy != "" {
+		return s.Data.Policy, nil
+	}
+	return s.Data.Rules, nil
+}
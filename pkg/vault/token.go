@@ -0,0 +1,54 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/vault-client-go/schema"
+)
+
+// TokenStatus is the lifetime of the current token, ExpireTime is zero for tokens without expiration
+type TokenStatus struct {
+	ExpireTime time.Time
+	TTL        time.Duration
+	Renewable  bool
+}
+
+// ReadTokenStatus looks up the current token
+func (v Vault) ReadTokenStatus() (TokenStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	s, err := v.cli.Auth.TokenLookUpSelf(ctx)
+	if err != nil {
+		return TokenStatus{}, err
+	}
+	return tokenStatus(s.Data), nil
+}
+
+// RenewToken renews the current token and returns its new status,
+// vault never extends the token beyond its max TTL
+func (v Vault) RenewToken() (TokenStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	if _, err := v.cli.Auth.TokenRenewSelf(ctx, schema.TokenRenewSelfRequest{}); err != nil {
+		return TokenStatus{}, err
+	}
+	return v.ReadTokenStatus()
+}
+
+func tokenStatus(data map[string]interface{}) TokenStatus {
+	var status TokenStatus
+	status.Renewable, _ = data["renewable"].(bool)
+	if ttl, ok := data["ttl"].(json.Number); ok {
+		if seconds, err := ttl.Int64(); err == nil {
+			status.TTL = time.Duration(seconds) * time.Second
+		}
+	}
+	if expireTime, ok := data["expire_time"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, expireTime); err == nil {
+			status.ExpireTime = t
+		}
+	}
+	return status
+}
@@ -0,0 +1,139 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValueType is json type of the secret value
+type ValueType string
+
+const (
+	TypeString ValueType = "string"
+	TypeNumber ValueType = "number"
+	TypeBool   ValueType = "bool"
+	TypeArray  ValueType = "array"
+	TypeObject ValueType = "object"
+	TypeNull   ValueType = "null"
+)
+
+// SecretValue keeps json type of the value next to its editable text,
+// strings are kept as they are, other types as (indented) json
+type SecretValue struct {
+	Type ValueType
+	Text string
+}
+
+func NewSecretValue(raw any) (SecretValue, error) {
+	switch v := raw.(type) {
+	case string:
+		return SecretValue{Type: TypeString, Text: v}, nil
+	case nil:
+		return SecretValue{Type: TypeNull, Text: "null"}, nil
+	case bool:
+		return SecretValue{Type: TypeBool, Text: strconv.FormatBool(v)}, nil
+	case json.Number:
+		return SecretValue{Type: TypeNumber, Text: v.String()}, nil
+	case float64, float32, int, int32, int64:
+		return SecretValue{Type: TypeNumber, Text: fmt.Sprintf("%v", v)}, nil
+	}
+	t := TypeObject
+	if _, ok := raw.([]interface{}); ok {
+		t = TypeArray
+	}
+	text, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return SecretValue{}, fmt.Errorf("Error marshaling Data: %v", err)
+	}
+	return SecretValue{Type: t, Text: string(text)}, nil
+}
+
+// Value parses text back to the value of the secret type
+func (sv SecretValue) Value() (any, error) {
+	switch sv.Type {
+	case TypeString, "":
+		return sv.Text, nil
+	case TypeBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(sv.Text))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a bool", sv.Text)
+		}
+		return b, nil
+	}
+	val, err := decodeJSON(sv.Text)
+	if err != nil {
+		return nil, err
+	}
+	if t := typeOf(val); t != sv.Type {
+		return nil, fmt.Errorf("expected %s, got %s", sv.Type, t)
+	}
+	return val, nil
+}
+
+// Validate checks text can be converted to the secret type
+func (sv SecretValue) Validate() error {
+	_, err := sv.Value()
+	return err
+}
+
+// Equal compares type and text of the values
+func (sv SecretValue) Equal(other SecretValue) bool {
+	return sv.Type == other.Type && sv.Text == other.Text
+}
+
+func decodeJSON(text string) (any, error) {
+	var val any
+	dec := json.NewDecoder(bytes.NewBufferString(text))
+	dec.UseNumber()
+	if err := dec.Decode(&val); err != nil {
+		return nil, fmt.Errorf("invalid json: %v", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid json: unexpected data after value")
+	}
+	return val, nil
+}
+
+func typeOf(val any) ValueType {
+	switch val.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBool
+	case json.Number:
+		return TypeNumber
+	case []interface{}:
+		return TypeArray
+	case map[string]interface{}:
+		return TypeObject
+	}
+	return TypeString
+}
+
+// SecretData converts secret values to data which can be written to vault
+func SecretData(secret map[string]SecretValue) (map[string]any, error) {
+	data := make(map[string]any)
+	for k, sv := range secret {
+		val, err := sv.Value()
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': %v", k, err)
+		}
+		data[k] = val
+	}
+	return data, nil
+}
+
+func secretValues(data map[string]interface{}) (map[string]SecretValue, error) {
+	sm := make(map[string]SecretValue)
+	for i, s := range data {
+		sv, err := NewSecretValue(s)
+		if err != nil {
+			return nil, err
+		}
+		sm[i] = sv
+	}
+	return sm, nil
+}
@@ -3,11 +3,14 @@ package vault
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"vaultview/pkg/config"
 	"vaultview/pkg/constants"
 
 	"github.com/hashicorp/vault-client-go"
@@ -15,43 +18,146 @@ import (
 )
 
 type VaultSvc interface {
-	ReadSecretEngines() ([]string, error)
+	ReadSecretEngines() ([]SecretEngine, error)
+	ReadAuthMethods() ([]AuthMount, error)
+	ListPolicies() ([]string, error)
+	ReadPolicy(name string) (string, error)
 	ListKvSecrets(mountPath, secretPath string) ([]string, error)
 	ReadTokenInfo() (map[string]string, error)
-	ReadKvSecret(mountPath, secretPath string) (map[string]string, map[string]string, error)
+	ReadTokenStatus() (TokenStatus, error)
+	RenewToken() (TokenStatus, error)
+	ReadKvSecret(mountPath, secretPath string) (map[string]SecretValue, map[string]string, error)
+	ReadKvSecretVersion(mountPath, secretPath string, version int) (map[string]SecretValue, map[string]string, error)
+	ReadKvSecretMetadata(mountPath, secretPath string) (KvMetadata, error)
+	RollbackKvSecret(mountPath, secretPath string, version int) error
+	DeleteKvSecret(mountPath, secretPath string) error
+	DeleteKvSecretVersions(mountPath, secretPath string, versions []int) error
+	UndeleteKvSecretVersions(mountPath, secretPath string, versions []int) error
+	DestroyKvSecretVersions(mountPath, secretPath string, versions []int) error
+	DeleteKvSecretMetadata(mountPath, secretPath string) error
+	WriteKvSecret(mountPath, secretPath string, updatedSecret map[string]any) error
 	WriteKv2Secret(mountPath, secretPath string, updatedSecret map[string]any) error
+	WriteKvSecretCas(mountPath, secretPath string, updatedSecret map[string]any, cas int) error
+	KvVersion(mountPath string) (int, error)
+	SetNamespace(namespace string) error
+	ListNamespaces() ([]string, error)
+	HTTPClient() *http.Client
+	Close()
+	Login(method, mountPath string, creds Credentials) error
+	OIDCLogin(ctx context.Context, mountPath, role, listenAddr string, showURL func(authURL string)) error
 	IsErrorStatus(err error, status int) bool
 }
 
+const (
+	KvV1 = 1
+	KvV2 = 2
+)
+
+// ErrCasConflict is returned when secret was changed since the version used for check-and-set
+var ErrCasConflict = errors.New("secret was changed in the meantime")
+
 type Vault struct {
-	cli *vault.Client
+	cli        *vault.Client
+	httpClient *http.Client
+	mounts     *mountVersions
+}
+
+// kv version per mount, filled from sys/mounts
+type mountVersions struct {
+	mx       sync.RWMutex
+	versions map[string]int
+}
+
+func (mv *mountVersions) get(mountPath string) (int, bool) {
+	mv.mx.RLock()
+	defer mv.mx.RUnlock()
+	ver, ok := mv.versions[mountPath]
+	return ver, ok
+}
+
+func (mv *mountVersions) set(mountPath string, ver int) {
+	mv.mx.Lock()
+	defer mv.mx.Unlock()
+	mv.versions[mountPath] = ver
+}
+
+func (mv *mountVersions) reset() {
+	mv.mx.Lock()
+	defer mv.mx.Unlock()
+	mv.versions = make(map[string]int)
 }
 
-func NewVault(addr, token string) (VaultSvc, error) {
+func NewVault(addr, token, namespace string, tlsCfg config.TLSConfig) (VaultSvc, error) {
+	// http client is configured by vault client (tls) and shared with other vault calls (health)
+	httpClient := vault.DefaultConfiguration().HTTPClient
 	client, err := vault.New(
 		vault.WithAddress(addr),
 		vault.WithRequestTimeout(30*time.Second),
+		vault.WithHTTPClient(httpClient),
+		vault.WithTLS(tlsConfiguration(tlsCfg)),
 	)
 
+	mounts := &mountVersions{versions: make(map[string]int)}
 	if err != nil {
 		return &Vault{
-			cli: client,
+			cli:        client,
+			httpClient: httpClient,
+			mounts:     mounts,
 		}, err
 	}
 
 	if err := client.SetToken(token); err != nil {
 		return &Vault{
-			cli: client,
+			cli:        client,
+			httpClient: httpClient,
+			mounts:     mounts,
+		}, err
+	}
+
+	if err := client.SetNamespace(strings.Trim(namespace, "/")); err != nil {
+		return &Vault{
+			cli:        client,
+			httpClient: httpClient,
+			mounts:     mounts,
 		}, err
 	}
 
 	return &Vault{
-		cli: client,
+		cli:        client,
+		httpClient: httpClient,
+		mounts:     mounts,
 	}, nil
 }
 
-func (v Vault) ReadSecretEngines() ([]string, error) {
-	secretEnignesNames := []string{}
+func tlsConfiguration(tlsCfg config.TLSConfig) vault.TLSConfiguration {
+	return vault.TLSConfiguration{
+		ServerCertificate: vault.ServerCertificateEntry{
+			FromFile:      tlsCfg.CACert,
+			FromDirectory: tlsCfg.CAPath,
+		},
+		ClientCertificate: vault.ClientCertificateEntry{
+			FromFile: tlsCfg.ClientCert,
+		},
+		ClientCertificateKey: vault.ClientCertificateKeyEntry{
+			FromFile: tlsCfg.ClientKey,
+		},
+		ServerName:         tlsCfg.ServerName,
+		InsecureSkipVerify: tlsCfg.SkipVerify,
+	}
+}
+
+// Close releases connections of the client, vault must not be used afterwards
+func (v Vault) Close() {
+	v.httpClient.CloseIdleConnections()
+}
+
+// HTTPClient returns http client with the same tls configuration as vault client
+func (v Vault) HTTPClient() *http.Client {
+	return v.httpClient
+}
+
+func (v Vault) ReadSecretEngines() ([]SecretEngine, error) {
+	engines := []SecretEngine{}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 	secretEngines, err := v.cli.System.MountsListSecretsEngines(ctx)
@@ -66,16 +172,71 @@ func (v Vault) ReadSecretEngines() ([]string, error) {
 	if len(secretEngines.Data) == 0 {
 		return nil, nil
 	}
-	for engine, _ := range secretEngines.Data {
-		secretEnignesNames = append(secretEnignesNames, engine[:len(engine)-1])
+	for engine, mount := range secretEngines.Data {
+		m, ok := mount.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		se := newSecretEngine(engine[:len(engine)-1], m)
+		if se.IsKv() {
+			v.mounts.set(se.Name, se.Version)
+		}
+		engines = append(engines, se)
 	}
-	return secretEnignesNames, nil
+	sort.Slice(engines, func(i, j int) bool {
+		return engines[i].Name < engines[j].Name
+	})
+	return engines, nil
+}
+
+// kvVersionFromMount reads kv version from mount type and options,
+// kv mounts without version option (and legacy generic mounts) are v1
+func kvVersionFromMount(mountType, options any) (int, bool) {
+	t, _ := mountType.(string)
+	if t != "kv" && t != "generic" {
+		return 0, false
+	}
+	if opts, ok := options.(map[string]interface{}); ok {
+		if ver, ok := opts["version"].(string); ok && ver == "2" {
+			return KvV2, true
+		}
+	}
+	return KvV1, true
+}
+
+// KvVersion returns kv version of the mount, if mount is not yet known
+// its configuration is read from vault
+func (v Vault) KvVersion(mountPath string) (int, error) {
+	if ver, ok := v.mounts.get(mountPath); ok {
+		return ver, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	m, err := v.cli.System.MountsReadConfiguration(ctx, mountPath)
+	if err != nil {
+		return 0, err
+	}
+	ver, ok := kvVersionFromMount(m.Data.Type, m.Data.Options)
+	if !ok {
+		return 0, fmt.Errorf("secret engine '%s' is not kv engine (type: %s)", mountPath, m.Data.Type)
+	}
+	v.mounts.set(mountPath, ver)
+	return ver, nil
 }
 
 func (v Vault) ListKvSecrets(mountPath, secretPath string) ([]string, error) {
+	ver, err := v.KvVersion(mountPath)
+	if err != nil {
+		return nil, err
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
-	s, err := v.cli.Secrets.KvV2List(ctx, secretPath, vault.WithMountPath(mountPath))
+	var s *vault.Response[schema.StandardListResponse]
+	if ver == KvV1 {
+		s, err = v.cli.Secrets.KvV1List(ctx, secretPath, vault.WithMountPath(mountPath))
+	} else {
+		s, err = v.cli.Secrets.KvV2List(ctx, secretPath, vault.WithMountPath(mountPath))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -85,21 +246,30 @@ func (v Vault) ListKvSecrets(mountPath, secretPath string) ([]string, error) {
 	return s.Data.Keys, nil
 }
 
-func (v Vault) ReadKvSecret(mountPath, secretPath string) (map[string]string, map[string]string, error) {
-	sm := make(map[string]string)
+func (v Vault) ReadKvSecret(mountPath, secretPath string) (map[string]SecretValue, map[string]string, error) {
+	return v.ReadKvSecretVersion(mountPath, secretPath, 0)
+}
+
+// ReadKvSecretVersion reads given version of the secret, version 0 is the latest one.
+// Version is ignored for kv v1 mounts.
+func (v Vault) ReadKvSecretVersion(mountPath, secretPath string, version int) (map[string]SecretValue, map[string]string, error) {
+	ver, err := v.KvVersion(mountPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ver == KvV1 {
+		return v.readKv1Secret(mountPath, secretPath)
+	}
 	metadata := make(map[string]string)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
-	s, err := v.cli.Secrets.KvV2Read(ctx, secretPath, vault.WithMountPath(mountPath))
+	s, err := v.cli.Secrets.KvV2Read(ctx, secretPath, vault.WithMountPath(mountPath), withVersion(version))
 	if err != nil {
 		return nil, nil, err
 	}
-	for i, s := range s.Data.Data {
-		json, err := json.Marshal(s)
-		if err != nil {
-			return nil, nil, fmt.Errorf("Error marshaling Data: %v", err)
-		}
-		sm[i] = prettifyString(string(json))
+	sm, err := secretValues(s.Data.Data)
+	if err != nil {
+		return nil, nil, err
 	}
 	for i, s := range s.Data.Metadata {
 		switch v := s.(type) {
@@ -118,24 +288,82 @@ func (v Vault) ReadKvSecret(mountPath, secretPath string) (map[string]string, ma
 	return sm, metadata, nil
 }
 
-func prettifyString(str string) string {
-	unquoted, _ := strconv.Unquote(str)
-	return unquoted
+func (v Vault) readKv1Secret(mountPath, secretPath string) (map[string]SecretValue, map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	s, err := v.cli.Secrets.KvV1Read(ctx, secretPath, vault.WithMountPath(mountPath))
+	if err != nil {
+		return nil, nil, err
+	}
+	sm, err := secretValues(s.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	// kv v1 has no metadata
+	return sm, make(map[string]string), nil
+}
+
+// WriteKvSecret writes secret using kv api matching the mount version
+func (v Vault) WriteKvSecret(mountPath, secretPath string, data map[string]any) error {
+	ver, err := v.KvVersion(mountPath)
+	if err != nil {
+		return err
+	}
+	if ver == KvV1 {
+		return v.WriteKv1Secret(mountPath, secretPath, data)
+	}
+	return v.WriteKv2Secret(mountPath, secretPath, data)
+}
+
+func (v Vault) WriteKv1Secret(mountPath, secretPath string, data map[string]any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	_, err := v.cli.Secrets.KvV1Write(ctx, secretPath, data, vault.WithMountPath(mountPath))
+	return err
 }
 
 func (v Vault) WriteKv2Secret(mountPath, secretPath string, data map[string]any) error {
+	return v.writeKv2Secret(mountPath, secretPath, data, nil)
+}
+
+// WriteKvSecretCas writes secret only if its current version matches cas version,
+// on mismatch returned error wraps ErrCasConflict. Cas is ignored for kv v1 mounts.
+func (v Vault) WriteKvSecretCas(mountPath, secretPath string, data map[string]any, cas int) error {
+	ver, err := v.KvVersion(mountPath)
+	if err != nil {
+		return err
+	}
+	if ver == KvV1 {
+		return v.WriteKv1Secret(mountPath, secretPath, data)
+	}
+	return v.writeKv2Secret(mountPath, secretPath, data, map[string]interface{}{"cas": cas})
+}
+
+func (v Vault) writeKv2Secret(mountPath, secretPath string, data map[string]any, options map[string]interface{}) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
-	resp, err := v.cli.Secrets.KvV2Write(ctx, secretPath, schema.KvV2WriteRequest{
-		Data: data,
+	_, err := v.cli.Secrets.KvV2Write(ctx, secretPath, schema.KvV2WriteRequest{
+		Data:    data,
+		Options: options,
 	},
 		vault.WithMountPath(mountPath),
 	)
-	if err != nil {
-		return err
+	if err != nil && isCasConflict(err) {
+		return fmt.Errorf("%w: %v", ErrCasConflict, err)
+	}
+	return err
+}
+
+func isCasConflict(err error) bool {
+	var responseError *vault.ResponseError
+	if errors.As(err, &responseError) && responseError.StatusCode == http.StatusBadRequest {
+		for _, e := range responseError.Errors {
+			if strings.Contains(e, "check-and-set") {
+				return true
+			}
+		}
 	}
-	fmt.Sprint(resp)
-	return nil
+	return false
 }
 
 func (v Vault) ReadTokenInfo() (map[string]string, error) {
@@ -0,0 +1,123 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
+)
+
+// SecretVersion is single version of kv v2 secret
+type SecretVersion struct {
+	Version      int
+	CreatedTime  string
+	DeletionTime string
+	Destroyed    bool
+}
+
+func (sv SecretVersion) IsDeleted() bool {
+	return sv.DeletionTime != ""
+}
+
+// KvMetadata is kv v2 metadata of the secret, versions are sorted from the newest
+type KvMetadata struct {
+	CurrentVersion int
+	OldestVersion  int
+	MaxVersions    int
+	CasRequired    bool
+	Versions       []SecretVersion
+}
+
+func withVersion(version int) vault.RequestOption {
+	if version <= 0 {
+		return vault.WithQueryParameters(url.Values{})
+	}
+	return vault.WithQueryParameters(url.Values{"version": {strconv.Itoa(version)}})
+}
+
+func (v Vault) ReadKvSecretMetadata(mountPath, secretPath string) (KvMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	s, err := v.cli.Secrets.KvV2ReadMetadata(ctx, secretPath, vault.WithMountPath(mountPath))
+	if err != nil {
+		return KvMetadata{}, err
+	}
+	sm := KvMetadata{
+		CurrentVersion: int(s.Data.CurrentVersion),
+		OldestVersion:  int(s.Data.OldestVersion),
+		MaxVersions:    int(s.Data.MaxVersions),
+		CasRequired:    s.Data.CasRequired,
+	}
+	for ver, data := range s.Data.Versions {
+		n, err := strconv.Atoi(ver)
+		if err != nil {
+			continue
+		}
+		sv := SecretVersion{Version: n}
+		if d, ok := data.(map[string]interface{}); ok {
+			sv.CreatedTime, _ = d["created_time"].(string)
+			sv.DeletionTime, _ = d["deletion_time"].(string)
+			sv.Destroyed, _ = d["destroyed"].(bool)
+		}
+		sm.Versions = append(sm.Versions, sv)
+	}
+	sort.Slice(sm.Versions, func(i, j int) bool {
+		return sm.Versions[i].Version > sm.Versions[j].Version
+	})
+	return sm, nil
+}
+
+// RollbackKvSecret writes data of the given version as the new current version,
+// data is written as it is stored in vault (values keep their types)
+func (v Vault) RollbackKvSecret(mountPath, secretPath string, version int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	s, err := v.cli.Secrets.KvV2Read(ctx, secretPath, vault.WithMountPath(mountPath), withVersion(version))
+	if err != nil {
+		return err
+	}
+	if s.Data.Data == nil {
+		return fmt.Errorf("version %d of secret '%s' has no data", version, secretPath)
+	}
+	data := make(map[string]any)
+	for k, val := range s.Data.Data {
+		data[k] = normalizeNumber(val)
+	}
+	_, err = v.cli.Secrets.KvV2Write(ctx, secretPath, schema.KvV2WriteRequest{
+		Data: data,
+	},
+		vault.WithMountPath(mountPath),
+	)
+	return err
+}
+
+// normalizeNumber turns json.Number (used by vault client decoder) back to int or float
+func normalizeNumber(val any) any {
+	switch v := val.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeNumber(e)
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeNumber(e)
+		}
+		return v
+	}
+	return val
+}